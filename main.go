@@ -1,23 +1,49 @@
 package main
 
 import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
 	"github.com/k3a/html2text"
 	"github.com/pkg/browser"
+	"github.com/rivo/uniseg"
 	"github.com/zalando/go-keyring"
 	miniflux "miniflux.app/v2/client"
 )
@@ -31,8 +57,27 @@ const (
 	StateLogin
 	StateHelp
 	StateLinks
+	StateSetup
+	StateFiles
+	StateDigest
+	StateArticleInfo
+	StateFeedHealth
+	StateStats
+	StateManageFeeds
 )
 
+// Setup Wizard Steps
+const (
+	setupStepWelcome = iota
+	setupStepCalibrate
+)
+
+// calibrationText is played during first-run setup to pick a starting WPM
+const calibrationText = "Speed reading works by flashing one word at a time in the same spot, " +
+	"so your eyes never have to move across the page. The red letter marks the point " +
+	"where your eye naturally lands. Adjust the speed until these sentences feel " +
+	"comfortable to follow, then press Enter to keep it."
+
 // Search Modes
 const (
 	SearchGeneral = iota
@@ -84,8 +129,61 @@ var (
 		lipgloss.Color("#fbf1c7"), // Gruvbox Light
 		lipgloss.Color("#ffffff"), // White
 	}
+
+	// Presets picked by Config.AutoTheme to match the system appearance
+	autoThemeDark  = 3 // One Dark
+	autoThemeLight = 4 // Gruvbox Light
+
+	// activeTheme is the palette currently applied to the styles, persisted so custom colors survive restarts
+	activeTheme ThemeColors
+
+	// noColor is set when NO_COLOR asks for no color (https://no-color.org); styles then use
+	// reverse video and bold instead, and themes can't be changed
+	noColor = os.Getenv("NO_COLOR") != ""
 )
 
+// ThemeColors is the full palette of a theme; an empty color means the terminal default
+type ThemeColors struct {
+	Background string `json:"background"`
+	Foreground string `json:"foreground"`
+	Focus      string `json:"focus"`
+	HUD        string `json:"hud"`
+	Line       string `json:"line"`
+}
+
+// themeFor derives the full palette for one of the preset backgrounds
+func themeFor(bg lipgloss.Color) ThemeColors {
+	t := ThemeColors{
+		Background: string(bg),
+		Foreground: "255", // White text default
+		Focus:      "196", // Red
+		HUD:        "240", // Grey default
+		Line:       "238", // Dark Grey
+	}
+
+	// Very rough heuristic for light themes
+	if bg == lipgloss.Color("#ffffff") || bg == lipgloss.Color("#fbf1c7") {
+		t.Foreground = "0" // Black text
+		t.HUD = "238"      // Darker grey for HUD
+	}
+	return t
+}
+
+// systemDarkMode reports whether macOS is set to dark mode; ok is false on other platforms
+// or when the setting can't be read
+func systemDarkMode() (dark bool, ok bool) {
+	if runtime.GOOS != "darwin" {
+		return false, false
+	}
+	out, err := exec.Command("defaults", "read", "-g", "AppleInterfaceStyle").Output()
+	if err != nil {
+		// The key only exists in dark mode, so defaults failing on its own means light mode
+		var exitErr *exec.ExitError
+		return false, errors.As(err, &exitErr)
+	}
+	return strings.TrimSpace(string(out)) == "Dark", true
+}
+
 // cleanTitle removes non-printable characters from a title and replaces newlines/carriage returns with spaces
 func cleanTitle(title string) string {
 	var sb strings.Builder
@@ -103,9 +201,21 @@ type model struct {
 	index              int
 	wpm                int
 	paused             bool
-	largeText          bool
+	textSize           int  // TextNormal, TextWide or TextBlock
+	bionic             bool // Bold the start of the word instead of coloring the ORP letter
 	rampSpeed          bool
 	zenMode            bool
+	punctBeat          bool // Showing the bare sentence punctuation after a word (PunctuationBeat mode)
+	pulsing            bool // The separators are lit for a metronome beat
+	lastPulse          time.Time
+	notice             string // Brief message shown until the next key, e.g. a speed limit being hit
+	showPauseMenu      bool   // Show quick actions while paused
+	sentenceCount      int    // Sentences read since the last reflection pause
+	reflecting         bool   // Paused automatically for reflection (ReflectEvery)
+	finished           bool   // The last word has had its full display time
+	seekDir            int    // Direction of the last seek: 1 forward, -1 back
+	seekStreak         int    // Seeks in a row in seekDir, each within seekRepeatWindow of the last
+	lastSeek           time.Time
 	width              int
 	height             int
 	previousState      int
@@ -125,18 +235,74 @@ type model struct {
 	searchInput    textinput.Model
 	urlInput       textinput.Model // For Miniflux URL input
 
+	// Find in Article
+	findInput   textinput.Model
+	finding     bool   // The find input has the keyboard
+	findTerm    string // Last term searched for
+	findMatches []int  // Word indices where the term starts
+
+	// Open by ID
+	idInput    textinput.Model
+	enteringID bool // The entry ID input has the keyboard
+
+	// Subscribe, adding a feed by URL and then picking its category from the category search
+	feedURLInput    textinput.Model
+	enteringFeedURL bool   // The feed URL input has the keyboard
+	subscribeURL    string // URL waiting for a category, set while the category list is picking for it
+
+	// Manage Feeds, moving feeds between categories and renaming them
+	feedsCursor    int
+	feedsOffset    int
+	movingFeed     *miniflux.Feed // Feed waiting for a category, set while the category list is picking for it
+	feedTitleInput textinput.Model
+	renamingFeed   bool // The feed title input has the keyboard
+
+	// Entries picked with Space for m and f to act on together
+	selected map[int64]bool
+
+	// Digits typed in the entry list, the 1-based number of the entry Enter jumps to
+	jumpNumber string
+
+	// Catch Up, marking old unread entries read after confirmation
+	catchUpIDs []int64 // Entries waiting for y to confirm, nil when not asking
+
 	// Search
 	searchMode      int
 	categories      miniflux.Categories
 	feeds           miniflux.Feeds
 	filteredList    []string
 	filteredIDs     []int64
+	searchSeq       int    // Bumped on each edit so only the last pause in typing searches
+	searchedTerm    string // Term last sent to the server
 	searchCursor    int
 	filteredEntries []*miniflux.Entry // For showing article previews in search
 
 	// Statistics
 	sessionArticles int
 	sessionWords    int
+	wordsAdvanced   int           // Words shown while playing, for effective WPM
+	activeReading   time.Duration // Time spent playing (not paused)
+	pauseCount      int           // Manual pauses (Space) while reading
+	pausedAt        time.Time     // When the current manual pause began, zero if not paused by Space
+	pausedTotal     time.Duration // Time spent in manual pauses
+	lastBreakAt     time.Duration // activeReading when the last break reminder was answered
+	breakDue        bool          // The break reminder is showing
+	sessionReads    []readRecord  // Articles finished this session, for --csv
+	rewindCount     int           // Presses of Left while reading
+	wordsReread     int           // Words stepped back over by those presses
+
+	detailedRows     bool             // Show feed and excerpt under each title
+	excerpts         map[int64]string // Plain-text excerpts by entry ID, converted on first use
+	contentCache     *contentCache    // Converted articles, prefetched ahead of Enter
+	prefetchSeq      int              // Bumped on each cursor move so only the last one prefetches
+	markReadNextID   int64            // Entry marked read with M, whose successor gets the cursor
+	pendingNext      bool             // J is waiting for the page being fetched, to open pendingNextIndex
+	pendingNextIndex int              // Index of the entry it opens then
+	glosses          *glossCache      // Definitions of words looked up with Config.GlossCommand
+
+	// Connection Status, from the outcome of the last server request
+	lastServerOK bool
+	lastServerAt time.Time // Zero until the first request completes
 
 	// Filters
 	filterYouTube     bool
@@ -146,10 +312,67 @@ type model struct {
 	// Configuration
 	cfg Config
 
+	emphasis  []bool        // Which words of content were bold or italic in the HTML; nil when none were
+	structure textStructure // Shape of the article being read, for the info shown before it starts
+
 	// Article Links
 	articleLinks    []ArticleLink
 	linksCursor     int
 	linksListOffset int
+
+	digestOffset int // Scroll position of the unread digest
+
+	// Feed Health
+	healthOffset  int  // Scroll position of the feed health list
+	checkingFeeds bool // Feeds are being fetched for the feed health view
+
+	// Directory Playlist
+	playlistDir    string
+	playlist       []playlistItem
+	playlistCursor int
+	playlistOffset int
+	currentFile    string // Name of the playlist file being read
+	advancing      bool   // The next file was opened by auto-advance and should start playing
+	autoAdvance    bool   // Auto-advance for this session, starting from Config.AutoAdvance and toggled with A
+	advanceIn      int    // Seconds left before auto-advance opens the next file, 0 if not counting down
+	advanceSeq     int    // Bumped to cancel a countdown whose ticks are still in flight
+	loadPath       string // File named on the command line, read once the TUI is up
+
+	// Reading position of a local file, remembered so it can be resumed
+	positionFile      string    // Absolute path of the file being read, empty for other text
+	positionSaved     int       // Word index last saved, -1 once the position has been cleared
+	positionSavedTime time.Time // When the position was last saved
+
+	// First-run setup
+	setupStep        int
+	setupReturnState int
+	setupStatus      string
+	calibrationWords []string
+	calibrationIndex int
+}
+
+// readRecord describes one finished article for the CSV export
+type readRecord struct {
+	Finished time.Time
+	Title    string
+	URL      string
+	Words    int
+	WPM      int
+}
+
+// feedDigest summarises the unread entries of one feed
+type feedDigest struct {
+	FeedID int64
+	Title  string
+	Count  int
+	Oldest time.Time
+}
+
+// playlistItem is a readable file in a directory opened as a playlist
+type playlistItem struct {
+	Name    string
+	Path    string
+	ModTime time.Time
 }
 
 // ArticleLink represents a link found in an article
@@ -163,27 +386,307 @@ func (m model) currentSearchTerm() string {
 	return m.searchInput.Value()
 }
 
+// readRecord describes the article that has just been finished
+func (m model) readRecord() readRecord {
+	r := readRecord{Finished: time.Now(), Words: len(m.content), WPM: m.wpm}
+	switch {
+	case m.currentEntry != nil:
+		r.Title = m.currentEntry.Title
+		r.URL = m.currentEntry.URL
+	case m.playlistDir != "" && m.currentFile != "":
+		r.Title = m.currentFile
+		r.URL = filepath.Join(m.playlistDir, m.currentFile)
+	default:
+		r.Title = m.currentFile
+	}
+	return r
+}
+
+// openAdjacentEntry opens the entry after (dir 1) or before (dir -1) the one being read,
+// fetching the next page first when J runs off the end of the loaded entries
+func (m model) openAdjacentEntry(dir int) (tea.Model, tea.Cmd) {
+	if m.minifluxClient == nil || m.currentEntry == nil || m.readingReturnState != StateBrowsing {
+		return m, nil
+	}
+
+	current := -1
+	for i, entry := range m.entries {
+		if entry.ID == m.currentEntry.ID {
+			current = i
+			break
+		}
+	}
+
+	var next int
+	switch {
+	case current >= 0:
+		next = current + dir
+	case dir > 0:
+		// A finished article is marked read and dropped from the list, leaving the cursor on the entry after it
+		next = m.cursor
+	default:
+		next = m.cursor - 1
+	}
+
+	if next < 0 {
+		return m, nil
+	}
+	if next >= len(m.entries) {
+		if m.entriesOffset >= m.totalEntries {
+			return m, nil // Nothing more on the server
+		}
+		m.pendingNext = true
+		m.pendingNextIndex = next
+		if m.fetchingMore {
+			return m, nil // The page is already on its way
+		}
+		m.fetchingMore = true
+		return m, fetchEntries(m.minifluxClient, m.currentSearchTerm(), m.currentCategoryID, m.currentFeedID, m.entriesOffset, m.filterYouTube)
+	}
+
+	m.paused = true
+	m.cursor = next
+	return m.openEntry(m.entries[next], StateBrowsing)
+}
+
+// listRowHeight is how many lines each entry takes in the browsing list
+func (m model) listRowHeight() int {
+	if m.detailedRows {
+		return 2
+	}
+	return 1
+}
+
+// excerptLength caps cached excerpts, which only ever show a few lines
+const excerptLength = 1000
+
+// excerpt returns the start of an entry's content as plain text on one line, caching the conversion
+func (m model) excerpt(entry *miniflux.Entry) string {
+	if text, ok := m.excerpts[entry.ID]; ok {
+		return text
+	}
+	text := strings.Join(strings.Fields(html2text.HTML2Text(entry.Content)), " ")
+	if runes := []rune(text); len(runes) > excerptLength {
+		text = string(runes[:excerptLength])
+	}
+	if m.excerpts != nil {
+		m.excerpts[entry.ID] = text
+	}
+	return text
+}
+
+// glyphSet holds the non-ASCII characters drawn in the interface, so they can be swapped as one
+type glyphSet struct {
+	Ellipsis  string
+	MoreAbove string // Scroll indicators at the top and bottom of lists
+	MoreBelow string
+	Star      string
+	Selected  string // Mark on entries picked with Space
+	Crumb     string // Between the list title and its filter
+	Dot       string // Between details on one line
+	Dash      string // Between a status and what to do about it
+	Status    string // Connection indicator, colored by the outcome of the last request
+	Block     string // Fills the block letters of the huge text size
+	Separator string // Replaces the default SeparatorChar
+	Bars      []rune // Sparkline levels, lowest first
+}
+
+var (
+	unicodeGlyphs = glyphSet{Ellipsis: "…", MoreAbove: "▲", MoreBelow: "▼", Star: "★", Selected: "✓", Crumb: "›", Dot: "·", Dash: "—", Status: "●", Block: "█", Separator: defaultSeparatorChar, Bars: []rune("▁▂▃▄▅▆▇█")}
+	asciiGlyphs   = glyphSet{Ellipsis: "...", MoreAbove: "^", MoreBelow: "v", Star: "*", Selected: "+", Crumb: ">", Dot: "-", Dash: "--", Status: "*", Block: "#", Separator: "-", Bars: []rune(".:-=+*#@")}
+
+	// glyph is the set in use, ASCII when Config.ASCIIMode is on
+	glyph = unicodeGlyphs
+)
+
+// applyGlyphs picks the glyph set for the config
+func applyGlyphs(cfg Config) {
+	glyph = unicodeGlyphs
+	if cfg.ASCIIMode {
+		glyph = asciiGlyphs
+	}
+}
+
+// truncateToWidth shortens s to fit in width cells, ending it with an ellipsis if anything was cut.
+// It cuts between grapheme clusters so accents and emoji sequences stay whole.
+func truncateToWidth(s string, width int) string {
+	if uniseg.StringWidth(s) <= width {
+		return s
+	}
+	targetWidth := max(width-uniseg.StringWidth(glyph.Ellipsis), 0)
+
+	var currentWidth int
+	var sb strings.Builder
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		w := g.Width()
+		if currentWidth+w > targetWidth {
+			break
+		}
+		sb.WriteString(g.Str())
+		currentWidth += w
+	}
+	return sb.String() + glyph.Ellipsis
+}
+
+// recordServer notes the outcome of a server request for the connection indicator
+func (m model) recordServer(err error) model {
+	m.lastServerOK = err == nil
+	m.lastServerAt = time.Now()
+	return m
+}
+
+// connectionStatus renders a coloured dot for the last server request, with when it happened
+func (m model) connectionStatus() string {
+	if m.lastServerAt.IsZero() {
+		return lineStyle.Render(glyph.Status + " connecting")
+	}
+	if m.lastServerOK {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render(glyph.Status) + lineStyle.Render(" connected "+m.lastServerAt.Format("15:04"))
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(glyph.Status) + lineStyle.Render(" failed "+m.lastServerAt.Format("15:04"))
+}
+
+// maybeFetchMore requests the next page once the cursor is within Config.PrefetchThreshold
+// entries of the end, unless a fetch is already running or everything is loaded
+func (m model) maybeFetchMore() (model, tea.Cmd) {
+	if m.fetchingMore || m.minifluxClient == nil || m.entriesOffset >= m.totalEntries {
+		return m, nil
+	}
+	if m.cursor < len(m.entries)-m.cfg.PrefetchThreshold {
+		return m, nil
+	}
+	m.fetchingMore = true
+	return m, fetchEntries(m.minifluxClient, m.currentSearchTerm(), m.currentCategoryID, m.currentFeedID, m.entriesOffset, m.filterYouTube)
+}
+
+// feedTitle names a feed for the header, from the feed list or else from a loaded entry
+func (m model) feedTitle(id int64) string {
+	for _, f := range m.feeds {
+		if f.ID == id {
+			return f.Title
+		}
+	}
+	for _, entry := range m.entries {
+		if entry.FeedID == id && entry.Feed != nil {
+			return entry.Feed.Title
+		}
+	}
+	return fmt.Sprintf("#%d", id)
+}
+
+// categoryTitle names a category for the header
+func (m model) categoryTitle(id int64) string {
+	for _, c := range m.categories {
+		if c.ID == id {
+			return c.Title
+		}
+	}
+	return fmt.Sprintf("#%d", id)
+}
+
 type tickMsg time.Time
 type entriesMsg struct {
 	result     *miniflux.EntryResultSet
 	offset     int
 	nextOffset int
+	search     string // The search term the entries were fetched for
+}
+type searchTickMsg struct {
+	seq int
+}
+type searchResultsMsg struct {
+	search  string // The search term the entries were found for
+	entries []*miniflux.Entry
 }
 type categoriesMsg miniflux.Categories
 type feedsMsg miniflux.Feeds
 type contentMsg struct {
-	text  string
-	links []ArticleLink
+	text      string
+	words     []string // The text split for the reader, without emphasis marks
+	emphasis  []bool
+	structure textStructure
+	links     []ArticleLink
 }
 type errMsg error
 type markReadMsg struct {
 	id  int64
 	err error
 }
+type batchReadMsg struct {
+	ids []int64
+	err error
+}
+type subscribedMsg struct {
+	feedID int64
+	err    error
+}
+type feedUpdatedMsg struct {
+	feed *miniflux.Feed
+	err  error
+}
+type oldUnreadMsg struct {
+	ids []int64
+	err error
+}
+type catchUpDoneMsg struct {
+	marked int
+	err    error
+}
+type entryFetchedMsg struct {
+	id    int64
+	entry *miniflux.Entry
+	err   error
+}
 type starredMsg struct {
 	id  int64
 	err error
 }
+type fileLoadErrMsg struct {
+	path string
+	err  error
+}
+type pulseEndMsg struct{}
+type prefetchTickMsg struct {
+	seq int
+}
+type advanceTickMsg struct {
+	seq int
+}
+type prefetchedMsg struct {
+	id      int64
+	content contentMsg
+}
+type editedMsg struct {
+	text string
+	err  error
+}
+type glossMsg struct {
+	key  string
+	text string
+}
+type mediaMsg struct {
+	err error
+}
+type articleSavedMsg struct {
+	path string
+	err  error
+}
+type positionSavedMsg struct {
+	err error
+}
+type problemLoggedMsg struct {
+	path string
+	err  error
+}
+type clipboardMsg struct {
+	text string
+	err  error
+}
+type connectionTestMsg struct {
+	username string
+	err      error
+}
 
 func initialModel(fileContent string, client *miniflux.Client, initialCfg Config) model {
 	ti := textinput.New()
@@ -197,20 +700,53 @@ func initialModel(fileContent string, client *miniflux.Client, initialCfg Config
 	urlTi.CharLimit = 200
 	urlTi.Width = 50
 
+	findTi := textinput.New()
+	findTi.Placeholder = "Find in article..."
+	findTi.CharLimit = 100
+	findTi.Width = 30
+
+	idTi := textinput.New()
+	idTi.Placeholder = "Entry ID"
+	idTi.CharLimit = 19 // Fits any int64
+	idTi.Width = 20
+
+	feedTitleTi := textinput.New()
+	feedTitleTi.Placeholder = "Feed title"
+	feedTitleTi.CharLimit = 200
+	feedTitleTi.Width = 40
+
+	feedURLTi := textinput.New()
+	feedURLTi.Placeholder = "Feed or website URL"
+	feedURLTi.CharLimit = 500
+	feedURLTi.Width = 40
+
 	m := model{
 		wpm:            initialCfg.WPM,
 		paused:         true,
 		rampSpeed:      initialCfg.RampSpeed,
 		zenMode:        initialCfg.ZenMode,
+		textSize:       initialCfg.TextSize,
+		bionic:         initialCfg.Bionic,
+		autoAdvance:    initialCfg.AutoAdvance,
 		minifluxClient: client,
 		searchInput:    ti,
 		urlInput:       urlTi,
+		findInput:      findTi,
+		idInput:        idTi,
+		feedURLInput:   feedURLTi,
+		feedTitleInput: feedTitleTi,
 		cfg:            initialCfg,
+		showPauseMenu:  initialCfg.PauseMenu,
+		excerpts:       make(map[int64]string),
+		contentCache:   newContentCache(contentCacheLimit),
+		glosses:        newGlossCache(),
+
+		calibrationWords: strings.Fields(calibrationText),
 	}
 
 	if fileContent != "" {
 		m.state = StateReading
-		m.content = strings.Fields(fileContent)
+		m.content = prepareWords(fileContent, initialCfg)
 	} else if client != nil { // Miniflux client was successfully created (from env or keyring)
 		m.state = StateBrowsing
 		m.loading = true
@@ -228,33 +764,235 @@ const (
 	minifluxKeyringUser    = "miniflux-token"
 )
 
-func getMinifluxToken() (string, error) {
+// Token Stores
+const (
+	TokenStoreKeyring = "keyring"
+	TokenStoreFile    = "file"
+)
+
+// tokenStoreName returns the effective token store, treating an unset value as the keyring
+func tokenStoreName(cfg Config) string {
+	if cfg.TokenStore == TokenStoreFile {
+		return TokenStoreFile
+	}
+	return TokenStoreKeyring
+}
+
+// getMinifluxToken reads the token from the store selected in the config
+func getMinifluxToken(cfg Config) (string, error) {
+	if tokenStoreName(cfg) == TokenStoreFile {
+		return loadFileToken()
+	}
 	return keyring.Get(minifluxKeyringService, minifluxKeyringUser)
 }
 
-func saveMinifluxToken(token string) error {
+// saveMinifluxToken writes the token to the store selected in the config
+func saveMinifluxToken(cfg Config, token string) error {
+	if tokenStoreName(cfg) == TokenStoreFile {
+		return saveFileToken(token)
+	}
 	return keyring.Set(minifluxKeyringService, minifluxKeyringUser, token)
 }
 
+// Encrypted File Token Store
+// Used where no OS keyring is available (e.g. headless servers without a Secret Service).
+const tokenKeyIterations = 600000
+
+// tokenPassphrase protects the token file; it is read from SPEEDREADER_PASSPHRASE or prompted for at startup
+var tokenPassphrase string
+
+type tokenFile struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func getTokenFilePath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), "speedreader_token.enc")
+}
+
+// ensureTokenPassphrase loads the passphrase from the environment, or asks for it on the terminal
+func ensureTokenPassphrase() error {
+	if tokenPassphrase != "" {
+		return nil
+	}
+	if p := os.Getenv("SPEEDREADER_PASSPHRASE"); p != "" {
+		tokenPassphrase = p
+		return nil
+	}
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return errors.New("no passphrase available: set SPEEDREADER_PASSPHRASE")
+	}
+
+	fmt.Fprint(os.Stderr, "Token file passphrase: ")
+	p, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return err
+	}
+	if len(p) == 0 {
+		return errors.New("passphrase must not be empty")
+	}
+	tokenPassphrase = string(p)
+	return nil
+}
+
+func tokenCipher(salt []byte) (cipher.AEAD, error) {
+	if tokenPassphrase == "" {
+		return nil, errors.New("no passphrase available: set SPEEDREADER_PASSPHRASE")
+	}
+	key, err := pbkdf2.Key(sha256.New, tokenPassphrase, salt, tokenKeyIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func loadFileToken() (string, error) {
+	data, err := os.ReadFile(getTokenFilePath())
+	if err != nil {
+		return "", err
+	}
+	var tf tokenFile
+	if err := json.Unmarshal(data, &tf); err != nil {
+		return "", fmt.Errorf("token file is corrupt: %w", err)
+	}
+	aead, err := tokenCipher(tf.Salt)
+	if err != nil {
+		return "", err
+	}
+	plain, err := aead.Open(nil, tf.Nonce, tf.Ciphertext, nil)
+	if err != nil {
+		return "", errors.New("could not decrypt token file (wrong passphrase?)")
+	}
+	return string(plain), nil
+}
+
+func saveFileToken(token string) error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	aead, err := tokenCipher(salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(tokenFile{
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, []byte(token), nil),
+	})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(getTokenFilePath(), data, 0600)
+}
+
+// offerFileTokenStore asks whether to switch to the encrypted file store after a keyring failure
+func offerFileTokenStore() bool {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return false
+	}
+	fmt.Fprint(os.Stderr, "The system keyring is unavailable. Store the Miniflux token in an encrypted file instead? [y/N] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
 func (m model) Init() tea.Cmd {
+	if m.loadPath != "" {
+		return loadInputFile(m.loadPath, m.cfg)
+	}
 	if m.state == StateBrowsing && m.minifluxClient != nil {
 		return tea.Batch(
 			fetchEntries(m.minifluxClient, "", 0, 0, 0, false),
 			refreshErroredFeedsOnStart(m.minifluxClient),
 		)
 	}
+	if m.state == StateReading && !m.paused && len(m.content) > 0 {
+		return tick(m.currentDelay())
+	}
 	return nil
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	before := m.highlightedID()
+	updated, cmd := m.update(msg)
+
+	// Once the cursor settles on a different entry, convert it ahead of Enter
+	if um, ok := updated.(model); ok && um.state == StateBrowsing {
+		if id := um.highlightedID(); id != 0 && id != before {
+			um.prefetchSeq++
+			seq := um.prefetchSeq
+			debounce := tea.Tick(prefetchDelay, func(time.Time) tea.Msg { return prefetchTickMsg{seq: seq} })
+			return um, tea.Batch(cmd, debounce)
+		}
+	}
+
+	// Remember where a file is up to, every PositionSaveWords words and on leaving the reader
+	if um, ok := updated.(model); ok && um.positionFile != "" && !um.loading {
+		if um.finished && um.positionSaved != -1 {
+			um.positionSaved = -1
+			return um, tea.Batch(cmd, savePosition(um.positionFile, -1, 0))
+		}
+		if um.positionDue(m.state == StateReading && um.state != StateReading) {
+			um.positionSaved = um.index
+			um.positionSavedTime = time.Now()
+			return um, tea.Batch(cmd, savePosition(um.positionFile, um.index, len(um.content)))
+		}
+		updated = um
+	}
+
+	// Look up glosses for the words coming up, in the background so the tick loop never waits
+	if um, ok := updated.(model); ok && um.state == StateReading && um.glossActive() {
+		if lookups := um.glossLookups(); lookups != nil {
+			return um, tea.Batch(cmd, lookups)
+		}
+	}
+	return updated, cmd
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Global keys (except when searching or logging in, where keys go to text input)
-		if m.state != StateSearching && m.state != StateLogin {
+		m.notice = "" // Notices last until the next key
+		if m.breakDue {
+			switch msg.String() {
+			case "ctrl+c", "q", "esc":
+				// Quitting and going back still work, taking the reminder down on the way
+				m.breakDue = false
+				m.lastBreakAt = m.activeReading
+			default:
+				return m.answerBreak(msg)
+			}
+		}
+		if m.advanceIn > 0 {
+			// Any key stops the auto-advance countdown; Esc then goes back to the list as usual
+			m.advanceIn = 0
+			m.advanceSeq++
+			m.notice = "auto-advance stopped"
+		}
+		// Global keys (except when keys go to a text input)
+		if !m.capturesText() {
 			switch msg.String() {
-			case "ctrl+c", "q":
+			case "ctrl+c":
+				return m, tea.Quit
+
+			case "q":
+				if m.cfg.QGoesBack && m.canGoBack() {
+					return m.backToList()
+				}
 				return m, tea.Quit
 
 			case "?": // help menu
@@ -269,25 +1007,53 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					// Return to previous state
 					m.state = m.previousState
 					return m, nil
-				}
-				// Prevent uses not from the Miniflux menu hitting this block
-				if (m.state == StateReading || m.state == StateYouTubeLink) && m.minifluxClient != nil {
-					if m.state == StateReading {
-						m.paused = true
+				case StateSetup:
+					// Skip the rest of the wizard
+					return m.finishSetup()
+				case StateDigest, StateFeedHealth, StateManageFeeds:
+					m.state = StateBrowsing
+					return m, nil
+				case StateStats:
+					return m.leaveStats()
+				case StateBrowsing:
+					if m.jumpNumber != "" {
+						m.jumpNumber = ""
+						return m, nil
 					}
-
-					if m.readingReturnState == StateSearching {
-						m.state = StateSearching
-					} else {
-						m.state = StateBrowsing
+					if len(m.selected) > 0 {
+						m.selected = nil
+						return m, nil
+					}
+					if !m.cfg.EscQuitsFromList {
+						m.notice = "press q to quit"
+						return m, nil
 					}
-					return m, nil
+				}
+				if m.canGoBack() {
+					return m.backToList()
 				}
 				return m, tea.Quit
 
-			case "c":
-				currentTheme = (currentTheme + 1) % len(themes)
-				updateTheme(themes[currentTheme])
+			case "c", "C":
+				if noColor {
+					m.notice = "themes are off while NO_COLOR is set"
+					break
+				}
+				step := 1
+				if msg.String() == "C" {
+					step = len(themes) - 1
+				}
+				currentTheme = (currentTheme + step) % len(themes)
+				updateTheme(themeFor(themes[currentTheme]))
+
+			case "A": // Override auto-advance for this session only
+				if m.playlistDir != "" {
+					m.autoAdvance = !m.autoAdvance
+					m.notice = "auto-advance off"
+					if m.autoAdvance {
+						m.notice = "auto-advance on"
+					}
+				}
 
 			case "o": // Open in browser
 				var url string
@@ -311,16 +1077,36 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 
-			case "f": // Toggle Starred
-				var entryID int64
-				if m.currentEntry != nil {
-					entryID = m.currentEntry.ID
-				} else if m.state == StateBrowsing && len(m.entries) > 0 {
-					entryID = m.entries[m.cursor].ID
+			case "W": // Open the entry in the Miniflux web UI
+				if entry := m.keyEntry(); entry != nil && m.cfg.MinifluxURL != "" {
+					_ = browser.OpenURL(entryWebURL(m.cfg.MinifluxURL, entry))
+				}
+
+			case "a": // Open the first media attachment
+				if entry := m.keyEntry(); entry != nil {
+					if len(entry.Enclosures) == 0 {
+						m.notice = "no media attached"
+						return m, nil
+					}
+					m.paused = true
+					return m, openEnclosure(entry.Enclosures[0], m.cfg.MediaPlayer)
+				}
+
+			case "!": // Note a broken entry for later
+				words := len(m.content)
+				if m.state == StateBrowsing {
+					words = -1 // Not converted yet
+				}
+				if entry := m.keyEntry(); entry != nil {
+					return m, logProblem(entry, m.feedTitle(entry.FeedID), words)
 				}
 
-				if entryID != 0 && m.minifluxClient != nil {
-					return m, toggleStarred(m.minifluxClient, entryID)
+			case "f": // Toggle Starred
+				if m.state == StateBrowsing && len(m.selected) > 0 && m.minifluxClient != nil {
+					return m.starSelected()
+				}
+				if entry := m.keyEntry(); entry != nil && m.minifluxClient != nil {
+					return m, toggleStarred(m.minifluxClient, entry.ID)
 				}
 			}
 		}
@@ -328,38 +1114,105 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// State Specific Handling
 		switch m.state {
 		case StateReading:
+			if len(m.content) == 0 {
+				return m, nil // Still loading, or nothing to read
+			}
+			if m.finding {
+				return m.updateFind(msg)
+			}
 			switch msg.String() {
+			case "/":
+				m.paused = true
+				m.finding = true
+				m.findInput.SetValue(m.findTerm)
+				m.findInput.CursorEnd()
+				m.findInput.Focus()
+				return m, textinput.Blink
+			case "n":
+				m = m.jumpToMatch(1)
+			case "N":
+				m = m.jumpToMatch(-1)
 			case " ":
 				m.paused = !m.paused
-				if !m.paused {
+				m.reflecting = false
+				m.finished = false
+				if m.paused {
+					m.pauseCount++
+					m.pausedAt = time.Now()
+				} else {
+					if !m.pausedAt.IsZero() {
+						m.pausedTotal += time.Since(m.pausedAt)
+						m.pausedAt = time.Time{}
+					}
+
+					// Step back a little to pick the thread up again
+					if m.cfg.ResumeRewind > 0 && m.index > 0 {
+						m.index = max(m.index-m.cfg.ResumeRewind, 0)
+						m.punctBeat = false
+					}
 					return m, tick(m.currentDelay())
 				}
 			case "s":
-				m.largeText = !m.largeText
+				m.textSize = (m.textSize + 1) % textSizeLevels
 			case "r":
 				m.rampSpeed = !m.rampSpeed
 			case "z":
 				m.zenMode = !m.zenMode
+			case "b":
+				m.bionic = !m.bionic
+			case "e":
+				// Trim the text in an external editor, then start again from the top
+				m.paused = true
+				return m, editContent(m.content)
+			case "w":
+				return m, saveArticle(m.cfg.SaveDir, m.savedTitle(), m.savedURL(), m.content)
+			case "p":
+				m.showPauseMenu = !m.showPauseMenu
+			case "m":
+				if m.minifluxClient != nil && m.currentEntry != nil {
+					return m, markAsRead(m.minifluxClient, m.currentEntry.ID)
+				}
+			case "J":
+				return m.openAdjacentEntry(1)
+			case "K":
+				return m.openAdjacentEntry(-1)
 			case "up", "k":
-				m.wpm += 50
+				m = m.adjustWPM(wpmStep)
 			case "down", "j":
-				if m.wpm > 50 {
-					m.wpm -= 50
-				}
+				m = m.adjustWPM(-wpmStep)
 			case "right":
-				m.index += 10
+				m = m.resetRhythm()
+				m = m.trackSeek(1)
+				m.index += m.seekStep()
 				if m.index >= len(m.content) {
 					m.index = len(m.content) - 1
 				}
 			case "left":
-				m.index -= 10
+				m = m.resetRhythm()
+				m = m.trackSeek(-1)
+				before := m.index
+				m.index -= m.seekStep()
 				if m.index < 0 {
 					m.index = 0
 				}
+				m.rewindCount++
+				m.wordsReread += before - m.index
 			case "g":
+				m = m.resetRhythm()
 				m.index = 0
 			case "G":
+				m = m.resetRhythm()
 				m.index = len(m.content) - 1
+			case "R":
+				// Unlike g, restarting stops so the article can be started again deliberately
+				if !m.paused {
+					m.paused = true
+					m.pauseCount++
+					m.pausedAt = time.Now()
+				}
+				m = m.resetRhythm()
+				m.index = 0
+				m.notice = "restarted, Space to play"
 			case "l":
 				// Show article links
 				if len(m.articleLinks) > 0 {
@@ -407,12 +1260,62 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		case StateBrowsing:
-			switch msg.String() {
-			case "/":
-				m.state = StateSearching
-				m.searchInput.Focus()
-				m.searchInput.SetValue("")
+			if m.enteringID {
+				return m.updateIDInput(msg)
+			}
+			if m.enteringFeedURL {
+				return m.updateFeedURLInput(msg)
+			}
+			if m.catchUpIDs != nil {
+				ids := m.catchUpIDs
+				m.catchUpIDs = nil
+				if msg.String() != "y" {
+					m.notice = "catch-up cancelled"
+					return m, nil
+				}
+				m.loading = true
+				return m, markEntriesRead(m.minifluxClient, ids)
+			}
+
+			// A number being typed is dropped by any key that doesn't carry on with it
+			if key := msg.String(); (len(key) != 1 || key[0] < '0' || key[0] > '9') && key != "enter" && key != "backspace" {
+				m.jumpNumber = ""
+			}
+			switch msg.String() {
+			case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+				if len(m.jumpNumber) < 6 {
+					m.jumpNumber += msg.String()
+				}
+			case "backspace":
+				if m.jumpNumber != "" {
+					m.jumpNumber = m.jumpNumber[:len(m.jumpNumber)-1]
+				}
+			case "X":
+				// Find the unread entries older than CatchUpDays, then ask before marking them
+				if m.minifluxClient != nil {
+					m.loading = true
+					return m, fetchOldUnreadIDs(m.minifluxClient, time.Now().AddDate(0, 0, -m.cfg.CatchUpDays))
+				}
+			case "+":
+				if m.minifluxClient != nil {
+					m.enteringFeedURL = true
+					m.feedURLInput.SetValue("")
+					m.feedURLInput.Focus()
+					return m, textinput.Blink
+				}
+			case "#":
+				if m.minifluxClient != nil {
+					m.enteringID = true
+					m.idInput.SetValue("")
+					m.idInput.Focus()
+					return m, textinput.Blink
+				}
+			case "/":
+				m.state = StateSearching
+				m.searchInput.Focus()
+				m.searchInput.SetValue("")
 				m.searchCursor = 0
+				m.searchedTerm = ""
 				// Initialize filtered entries with all current entries for General search
 				m.filteredEntries = m.entries
 				return m, textinput.Blink
@@ -431,6 +1334,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						visibleHeight--
 					}
 					visibleHeight-- // Reserve for bottom indicator
+					visibleHeight /= m.listRowHeight()
 
 					scrollOff := 2
 					if visibleHeight < scrollOff+1 {
@@ -441,6 +1345,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.listOffset = m.cursor - (visibleHeight - 1 - scrollOff)
 					m.listOffset = max(m.listOffset, 0)
 				}
+				return m.maybeFetchMore()
+			case "pgup":
+				page := max((m.height-5)/m.listRowHeight(), 1) // Header and both scroll indicators
+				m.cursor = max(m.cursor-page, 0)
+				if m.cursor < m.listOffset {
+					m.listOffset = m.cursor
+				}
+			case "pgdown":
+				if len(m.entries) > 0 {
+					page := max((m.height-5)/m.listRowHeight(), 1)
+					m.cursor = min(m.cursor+page, len(m.entries)-1)
+					m.listOffset = min(m.listOffset+page, m.cursor)
+				}
+				return m.maybeFetchMore()
 			case "up", "k":
 				if m.cursor > 0 {
 					m.cursor--
@@ -453,11 +1371,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.cursor++
 				}
 				// Infinite Scroll Trigger
-				// If we are within 10 items of the end, and we haven't loaded all items, fetch more
-				if !m.fetchingMore && m.entriesOffset < m.totalEntries && m.cursor >= len(m.entries)-10 {
-					m.fetchingMore = true
-					cmd = fetchEntries(m.minifluxClient, m.currentSearchTerm(), m.currentCategoryID, m.currentFeedID, m.entriesOffset, m.filterYouTube)
-				}
+				m, cmd = m.maybeFetchMore()
 
 				// Ensure cursor is visible with scrolloff
 				headerHeight := 3
@@ -468,6 +1382,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					visibleHeight--
 				}
 				visibleHeight-- // Reserve for bottom indicator
+				visibleHeight /= m.listRowHeight()
 
 				scrollOff := 2
 				if visibleHeight < scrollOff+1 {
@@ -481,30 +1396,114 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, cmd
 
 			case "enter":
+				if m.jumpNumber != "" {
+					n, _ := strconv.Atoi(m.jumpNumber)
+					m.jumpNumber = ""
+					return m.jumpToEntry(n).maybeFetchMore()
+				}
 				if len(m.entries) > 0 {
-					selected := m.entries[m.cursor]
-					m.loading = true
-					m.currentEntry = selected // Store the selected entry
-
-					// Check if it's a YouTube video
-					if isYouTubeEntry(selected) {
-						m.state = StateYouTubeLink
-						m.loading = false // No content to fetch
-						m.readingReturnState = StateBrowsing
-						return m, nil
-					}
+					return m.openEntry(m.entries[m.cursor], StateBrowsing)
+				}
+			case "d":
+				m.detailedRows = !m.detailedRows
 
-					m.readingReturnState = StateBrowsing
-					return m, fetchContent(selected.Content)
+				// Taller rows fit fewer entries, so scroll the cursor back into view
+				rows := max((m.height-5)/m.listRowHeight(), 1)
+				if m.cursor >= m.listOffset+rows {
+					m.listOffset = m.cursor - rows + 1
+				}
+			case "v":
+				m.loading = true
+				return m, pasteClipboard
+			case "i":
+				m.digestOffset = 0
+				m.state = StateDigest
+				return m, nil
+			case "S":
+				m.state = StateStats
+				return m, nil
+			case "F":
+				// Fetch the feeds afresh so their titles and categories are current
+				if m.minifluxClient != nil {
+					m.feedsCursor = 0
+					m.feedsOffset = 0
+					m.checkingFeeds = true
+					m.err = nil
+					m.state = StateManageFeeds
+					return m, fetchFeeds(m.minifluxClient)
+				}
+			case "H":
+				// Fetch the feeds afresh so their error states are current
+				if m.minifluxClient != nil {
+					m.healthOffset = 0
+					m.checkingFeeds = true
+					m.err = nil
+					m.state = StateFeedHealth
+					return m, fetchFeeds(m.minifluxClient)
+				}
+			case ">":
+				// Drill into the selected entry's feed
+				if m.minifluxClient != nil && len(m.entries) > 0 {
+					m.currentCategoryID = 0
+					m.currentFeedID = m.entries[m.cursor].FeedID
+					m.searchInput.SetValue("")
+					m.loading = true
+					m.fetchingMore = false
+					return m, fetchEntries(m.minifluxClient, "", 0, m.currentFeedID, 0, m.filterYouTube)
+				}
+			case "<":
+				// Back out of a feed or category filter to all unread entries
+				if m.minifluxClient != nil && (m.currentFeedID != 0 || m.currentCategoryID != 0) {
+					m.currentCategoryID = 0
+					m.currentFeedID = 0
+					m.loading = true
+					m.fetchingMore = false
+					return m, fetchEntries(m.minifluxClient, m.currentSearchTerm(), 0, 0, 0, m.filterYouTube)
+				}
+			case "\\":
+				// Drop the feed, category and search filters to see every unread entry again
+				if m.minifluxClient != nil {
+					m.currentCategoryID = 0
+					m.currentFeedID = 0
+					m.searchInput.SetValue("")
+					m.searchedTerm = ""
+					m.notice = "Filters cleared: showing all unread"
+					m.loading = true
+					m.fetchingMore = false
+					return m, fetchEntries(m.minifluxClient, "", 0, 0, 0, m.filterYouTube)
 				}
 			case "y":
 				m.filterYouTube = !m.filterYouTube
 				m.loading = true
 				return m, fetchEntries(m.minifluxClient, m.currentSearchTerm(), m.currentCategoryID, m.currentFeedID, 0, m.filterYouTube)
+			case " ":
+				// Select the entry for m and f to act on with the others
+				if len(m.entries) > 0 {
+					id := m.entries[m.cursor].ID
+					if m.selected[id] {
+						delete(m.selected, id)
+					} else {
+						if m.selected == nil {
+							m.selected = make(map[int64]bool)
+						}
+						m.selected[id] = true
+					}
+				}
 			case "m":
 				// Mark as read manually
+				if ids := m.selectedIDs(); m.minifluxClient != nil && len(ids) > 0 {
+					m.selected = nil
+					return m, markBatchRead(m.minifluxClient, ids)
+				}
+				if m.minifluxClient != nil && len(m.entries) > 0 {
+					entryID := m.entries[m.cursor].ID
+					return m, markAsRead(m.minifluxClient, entryID)
+				}
+			case "M":
+				// Mark as read, then move on to (or open) the entry after it
 				if m.minifluxClient != nil && len(m.entries) > 0 {
 					entryID := m.entries[m.cursor].ID
+					m.markReadNextID = entryID
 					return m, markAsRead(m.minifluxClient, entryID)
 				}
 			case "r":
@@ -517,6 +1516,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case StateSearching:
 			switch msg.String() {
 			case "r":
+				if m.pickingCategory() {
+					break // Part of a category name
+				}
 				if m.minifluxClient != nil {
 					m.state = StateBrowsing
 					m.loading = true
@@ -525,6 +1527,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 			case "enter":
+				if m.subscribeURL != "" {
+					// Picking the category for a new feed rather than filtering by it
+					if len(m.filteredIDs) == 0 || m.searchCursor >= len(m.filteredIDs) {
+						return m, nil
+					}
+					feedURL := m.subscribeURL
+					m.subscribeURL = ""
+					m.state = StateBrowsing
+					m.loading = true
+					m.searchInput.Blur()
+					return m, subscribeFeed(m.minifluxClient, feedURL, m.filteredIDs[m.searchCursor])
+				}
+				if m.movingFeed != nil {
+					if len(m.filteredIDs) == 0 || m.searchCursor >= len(m.filteredIDs) {
+						return m, nil
+					}
+					feed := m.movingFeed
+					m.movingFeed = nil
+					m.state = StateManageFeeds
+					m.searchInput.Blur()
+					categoryID := m.filteredIDs[m.searchCursor]
+					return m, updateFeed(m.minifluxClient, feed.ID, &miniflux.FeedModificationRequest{CategoryID: &categoryID})
+				}
 				m.state = StateBrowsing
 				m.loading = true
 
@@ -536,20 +1561,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				switch m.searchMode {
 				case SearchGeneral:
+					// Enter searches straight away if the server hasn't seen this term yet
+					if m.minifluxClient != nil && searchTerm != m.searchedTerm {
+						m.state = StateSearching
+						m.loading = false
+						return m.runSearch()
+					}
 					if len(m.filteredEntries) > 0 && m.searchCursor < len(m.filteredEntries) {
-						selected := m.filteredEntries[m.searchCursor]
-						m.loading = true
-						m.currentEntry = selected
-
-						if isYouTubeEntry(selected) {
-							m.state = StateYouTubeLink
-							m.loading = false
-							m.readingReturnState = StateSearching
-							return m, nil
-						}
-
-						m.readingReturnState = StateSearching
-						return m, fetchContent(selected.Content)
+						return m.openEntry(m.filteredEntries[m.searchCursor], StateSearching)
 					}
 					m.state = StateSearching
 					m.loading = false
@@ -580,9 +1599,21 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "esc":
 				m.state = StateBrowsing
 				m.searchInput.Blur()
+				if m.subscribeURL != "" {
+					m.subscribeURL = ""
+					m.notice = "subscription cancelled"
+				}
+				if m.movingFeed != nil {
+					m.movingFeed = nil
+					m.state = StateManageFeeds
+				}
 				return m, nil
 
 			case "tab":
+				if m.pickingCategory() {
+					// The category is all that is left to choose
+					return m, nil
+				}
 				m.searchMode = (m.searchMode + 1) % len(searchModes)
 				m.searchInput.SetValue("")
 				m.searchCursor = 0
@@ -651,7 +1682,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+			previousTerm := m.searchInput.Value()
 			m.searchInput, cmd = m.searchInput.Update(msg)
+			if m.searchMode == SearchGeneral && m.minifluxClient != nil && m.searchInput.Value() != previousTerm {
+				m.searchSeq++
+				seq := m.searchSeq
+				cmd = tea.Batch(cmd, tea.Tick(searchDelay, func(time.Time) tea.Msg { return searchTickMsg{seq: seq} }))
+			}
 
 			// Post-update filtering
 			switch m.searchMode {
@@ -708,18 +1745,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						saveConfig(m.cfg)
 					}
 					if minifluxToken != "" {
-						if err := saveMinifluxToken(minifluxToken); err != nil {
+						if err := saveMinifluxToken(m.cfg, minifluxToken); err != nil {
 							m.err = fmt.Errorf("failed to save token: %w", err)
 						}
 					}
 
 					// Try to connect and switch state
 					if minifluxURL != "" && minifluxToken != "" {
-						m.minifluxClient = miniflux.NewClientWithOptions(
-							minifluxURL,
-							miniflux.WithAPIKey(minifluxToken),
-							miniflux.WithHTTPClient(&http.Client{Timeout: 60 * time.Second}),
-						)
+						m.minifluxClient = newMinifluxClient(minifluxURL, minifluxToken, m.cfg)
 						m.state = StateBrowsing
 						m.loading = true
 						m.urlInput.Blur()
@@ -751,6 +1784,168 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searchInput, cmd = m.searchInput.Update(msg)
 			}
 			return m, cmd
+		case StateArticleInfo:
+			switch msg.String() {
+			case " ", "enter":
+				m.state = StateReading
+				if len(m.content) > 0 {
+					m.paused = false
+					return m, tick(m.currentDelay())
+				}
+			case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+				// Open a media attachment by its number in the list
+				if i := int(msg.String()[0] - '1'); i < len(m.currentEntry.Enclosures) {
+					return m, openEnclosure(m.currentEntry.Enclosures[i], m.cfg.MediaPlayer)
+				}
+			}
+			return m, nil
+		case StateDigest:
+			switch msg.String() {
+			case "i":
+				m.state = StateBrowsing
+			case "up", "k":
+				if m.digestOffset > 0 {
+					m.digestOffset--
+				}
+			case "down", "j":
+				if m.digestOffset < len(digestByFeed(m.entries))-1 {
+					m.digestOffset++
+				}
+			}
+			return m, nil
+		case StateStats:
+			if msg.String() == "S" {
+				return m.leaveStats()
+			}
+			return m, nil
+		case StateManageFeeds:
+			if m.renamingFeed {
+				return m.updateFeedTitleInput(msg)
+			}
+			switch msg.String() {
+			case "F":
+				m.state = StateBrowsing
+			case "r":
+				m.checkingFeeds = true
+				m.err = nil
+				return m, fetchFeeds(m.minifluxClient)
+			case "up", "k":
+				if m.feedsCursor > 0 {
+					m.feedsCursor--
+				}
+				m = m.scrollFeeds()
+			case "down", "j":
+				if m.feedsCursor < len(m.feeds)-1 {
+					m.feedsCursor++
+				}
+				m = m.scrollFeeds()
+			case "m":
+				if m.feedsCursor < len(m.feeds) {
+					m.movingFeed = m.feeds[m.feedsCursor]
+					return m.pickCategory()
+				}
+			case "n":
+				if m.feedsCursor < len(m.feeds) {
+					m.renamingFeed = true
+					m.feedTitleInput.SetValue(m.feeds[m.feedsCursor].Title)
+					m.feedTitleInput.CursorEnd()
+					m.feedTitleInput.Focus()
+					return m, textinput.Blink
+				}
+			}
+			return m, nil
+		case StateFeedHealth:
+			switch msg.String() {
+			case "H":
+				m.state = StateBrowsing
+			case "r":
+				m.checkingFeeds = true
+				m.err = nil
+				return m, fetchFeeds(m.minifluxClient)
+			case "up", "k":
+				if m.healthOffset > 0 {
+					m.healthOffset--
+				}
+			case "down", "j":
+				if m.healthOffset < len(brokenFeeds(m.feeds))-1 {
+					m.healthOffset++
+				}
+			}
+			return m, nil
+		case StateFiles:
+			switch msg.String() {
+			case "up", "k":
+				if m.playlistCursor > 0 {
+					m.playlistCursor--
+				}
+			case "down", "j":
+				if m.playlistCursor < len(m.playlist)-1 {
+					m.playlistCursor++
+				}
+			case "g":
+				m.playlistCursor = 0
+			case "G":
+				m.playlistCursor = max(len(m.playlist)-1, 0)
+			case "s":
+				if m.cfg.PlaylistSort == PlaylistSortMTime {
+					m.cfg.PlaylistSort = PlaylistSortName
+				} else {
+					m.cfg.PlaylistSort = PlaylistSortMTime
+				}
+				sortPlaylist(m.playlist, m.cfg.PlaylistSort)
+				m.playlistCursor = 0
+			case "r":
+				items, err := loadPlaylist(m.playlistDir, m.cfg.PlaylistSort)
+				m.err = err
+				m.playlist = items
+				m.playlistCursor = min(m.playlistCursor, max(len(items)-1, 0))
+			case "enter":
+				if len(m.playlist) > 0 {
+					return m.openPlaylistItem(m.playlistCursor)
+				}
+			}
+
+			// Keep the cursor inside the visible window
+			visible := m.playlistVisibleRows()
+			if m.playlistCursor < m.playlistOffset {
+				m.playlistOffset = m.playlistCursor
+			} else if m.playlistCursor >= m.playlistOffset+visible {
+				m.playlistOffset = m.playlistCursor - visible + 1
+			}
+			return m, nil
+		case StateSetup:
+			switch m.setupStep {
+			case setupStepWelcome:
+				switch msg.String() {
+				case "enter":
+					m.setupStep = setupStepCalibrate
+					m.setupStatus = ""
+					m.calibrationIndex = 0
+					m.paused = true
+				case "t":
+					if m.minifluxClient == nil {
+						m.setupStatus = "No Miniflux credentials yet: enter them on the login screen after setup."
+						return m, nil
+					}
+					m.setupStatus = "Testing connection..."
+					return m, testConnection(m.minifluxClient)
+				}
+			case setupStepCalibrate:
+				switch msg.String() {
+				case " ":
+					m.paused = !m.paused
+					if !m.paused {
+						return m, tick(time.Minute / time.Duration(m.wpm))
+					}
+				case "up", "k":
+					m = m.adjustWPM(wpmStep)
+				case "down", "j":
+					m = m.adjustWPM(-wpmStep)
+				case "enter":
+					return m.finishSetup()
+				}
+			}
+			return m, nil
 		case StateLinks:
 			switch msg.String() {
 			case "esc", "l":
@@ -834,25 +2029,250 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 
 	case tickMsg:
+		if m.state == StateSetup {
+			// Calibration sample loops until the user accepts a speed
+			if m.setupStep != setupStepCalibrate || m.paused {
+				return m, nil
+			}
+			m.calibrationIndex = (m.calibrationIndex + 1) % len(m.calibrationWords)
+			return m, tick(time.Minute / time.Duration(m.wpm))
+		}
 		if m.state != StateReading || m.paused {
 			return m, nil
 		}
+
+		if m.punctBeat {
+			// The punctuation beat is over, move on from the word it belonged to
+			m.punctBeat = false
+			m.activeReading += m.beatDelay()
+		} else {
+			// The word on screen has just finished its display time
+			m.activeReading += m.currentDelay()
+			m.wordsAdvanced++
+
+			// Give sentence-ending punctuation its own beat before the next word
+			if m.cfg.PunctuationBeat && m.index < len(m.content)-1 && sentenceEnding(m.content[m.index]) != "" {
+				m.punctBeat = true
+				return m, tick(m.beatDelay())
+			}
+		}
+
 		if m.index >= len(m.content)-1 {
 			m.paused = true
+			m.finished = true
 
 			// Increment stats
 			m.sessionArticles++
 			m.sessionWords += len(m.content)
+			m.sessionReads = append(m.sessionReads, m.readRecord())
 
 			if m.minifluxClient != nil && m.currentEntry != nil {
+				// Re-reading an entry that is already read has nothing to update
+				if m.currentEntry.Status == miniflux.EntryStatusRead {
+					return m, nil
+				}
 				return m, markAsRead(m.minifluxClient, m.currentEntry.ID)
 			}
+
+			// Continue with the next file of a playlist
+			if m.readingReturnState == StateFiles && m.autoAdvance && m.playlistCursor < len(m.playlist)-1 {
+				if m.cfg.AutoAdvanceDelaySeconds > 0 {
+					m.advanceIn = m.cfg.AutoAdvanceDelaySeconds
+					m.advanceSeq++
+					return m, advanceTick(m.advanceSeq)
+				}
+				return m.advance()
+			}
+
+			switch m.cfg.OnFileFinish {
+			case FileFinishLoop:
+				m.index = 0
+				m.paused = false
+				m = m.resetRhythm()
+				return m, tick(m.currentDelay())
+			case FileFinishList:
+				if m.canGoBack() {
+					return m.backToList()
+				}
+			case FileFinishQuit:
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		// Remind to take a break after BreakReminderMinutes of reading
+		if m.cfg.BreakReminderMinutes > 0 && m.activeReading-m.lastBreakAt >= time.Duration(m.cfg.BreakReminderMinutes)*time.Minute {
+			m.index++
+			m.paused = true
+			m.breakDue = true
 			return m, nil
 		}
+
+		// Stop for reflection after every ReflectEvery sentences
+		if m.cfg.ReflectEvery > 0 && sentenceEnding(m.content[m.index]) != "" {
+			m.sentenceCount++
+			if m.sentenceCount >= m.cfg.ReflectEvery {
+				m.sentenceCount = 0
+				m.index++
+				m.paused = true
+				m.reflecting = true
+				return m, nil
+			}
+		}
+
 		m.index++
+		if cmd := m.metronomeBeat(); cmd != nil {
+			m.pulsing = m.cfg.Metronome == MetronomePulse
+			m.lastPulse = time.Now()
+			return m, tea.Batch(tick(m.currentDelay()), cmd)
+		}
 		return m, tick(m.currentDelay())
 
+	case pulseEndMsg:
+		m.pulsing = false
+
+	case advanceTickMsg:
+		if msg.seq != m.advanceSeq || m.advanceIn == 0 || m.state != StateReading {
+			return m, nil
+		}
+		m.advanceIn--
+		if m.advanceIn == 0 {
+			return m.advance()
+		}
+		return m, advanceTick(m.advanceSeq)
+
+	case searchTickMsg:
+		// Search once typing has paused, unless more keys came in since
+		if msg.seq == m.searchSeq && m.state == StateSearching && m.searchMode == SearchGeneral {
+			return m.runSearch()
+		}
+
+	case prefetchTickMsg:
+		// Only the last cursor move in a burst prefetches
+		if msg.seq == m.prefetchSeq && m.state == StateBrowsing && len(m.entries) > 0 {
+			return m, m.prefetch(m.entries[m.cursor])
+		}
+
+	case glossMsg:
+		m.glosses.put(msg.key, msg.text)
+
+	case prefetchedMsg:
+		m.contentCache.put(msg.id, msg.content)
+
+	case subscribedMsg:
+		m = m.recordServer(msg.err)
+		if msg.err != nil {
+			m.loading = false
+			m.notice = "subscribe failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.notice = fmt.Sprintf("subscribed (feed #%d)", msg.feedID)
+		m.fetchingMore = false
+		return m, tea.Batch(
+			fetchFeeds(m.minifluxClient),
+			fetchEntries(m.minifluxClient, m.currentSearchTerm(), m.currentCategoryID, m.currentFeedID, 0, m.filterYouTube),
+		)
+
+	case feedUpdatedMsg:
+		m = m.recordServer(msg.err)
+		if msg.err != nil {
+			m.notice = "feed update failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.notice = "saved " + cleanTitle(msg.feed.Title)
+		for i, f := range m.feeds {
+			if f.ID == msg.feed.ID {
+				m.feeds[i] = msg.feed
+			}
+		}
+		// Refetch so the feed cache, and the titles and categories shown with entries, catch up
+		return m, fetchFeeds(m.minifluxClient)
+
+	case oldUnreadMsg:
+		m.loading = false
+		m = m.recordServer(msg.err)
+		switch {
+		case msg.err != nil:
+			m.notice = "catch-up failed: " + msg.err.Error()
+		case len(msg.ids) == 0:
+			m.notice = fmt.Sprintf("nothing unread older than %d days", m.cfg.CatchUpDays)
+		case m.state == StateBrowsing:
+			m.catchUpIDs = msg.ids
+		}
+
+	case catchUpDoneMsg:
+		m = m.recordServer(msg.err)
+		if msg.err != nil {
+			m.loading = false
+			m.notice = fmt.Sprintf("catch-up stopped after %d entries: %v", msg.marked, msg.err)
+		} else {
+			m.notice = fmt.Sprintf("marked %s entries read", formatThousands(msg.marked))
+		}
+		if msg.marked > 0 {
+			// The list is out of date now, so load it again
+			m.loading = true
+			m.fetchingMore = false
+			return m, fetchEntries(m.minifluxClient, m.currentSearchTerm(), m.currentCategoryID, m.currentFeedID, 0, m.filterYouTube)
+		}
+
+	case entryFetchedMsg:
+		m.loading = false
+		switch {
+		case errors.Is(msg.err, miniflux.ErrNotFound):
+			m = m.recordServer(nil) // The server answered, there's just no such entry
+			m.notice = fmt.Sprintf("entry %d not found", msg.id)
+		case msg.err != nil:
+			m = m.recordServer(msg.err)
+			m.notice = fmt.Sprintf("couldn't fetch entry %d: %v", msg.id, msg.err)
+		default:
+			m = m.recordServer(nil)
+			if m.state == StateBrowsing {
+				return m.openEntry(msg.entry, StateBrowsing)
+			}
+		}
+
+	case articleSavedMsg:
+		if msg.err != nil {
+			m.notice = "couldn't save: " + msg.err.Error()
+		} else {
+			m.notice = "saved to " + msg.path
+		}
+
+	case positionSavedMsg:
+		if msg.err != nil {
+			m.notice = "couldn't save reading position: " + msg.err.Error()
+		}
+
+	case problemLoggedMsg:
+		if msg.err != nil {
+			m.notice = "couldn't log problem: " + msg.err.Error()
+		} else {
+			m.notice = "logged to " + msg.path
+		}
+
+	case mediaMsg:
+		if msg.err != nil {
+			m.notice = "couldn't open media: " + msg.err.Error()
+		}
+
+	case editedMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("edit failed: %w", msg.err)
+			return m, nil
+		}
+		words := prepareWords(msg.text, m.cfg)
+		if len(words) == 0 {
+			return m, nil // Emptying the file keeps the text as it was
+		}
+		m.err = nil
+		m.content = words
+		m.emphasis = nil // The editor only sees plain text
+		m.articleLinks = locateLinks(m.articleLinks, m.content)
+		m.index = 0
+		m = m.resetRhythm()
+		m.findMatches = findPhrase(m.content, m.findTerm)
+
 	case entriesMsg:
+		m = m.recordServer(nil)
 		if msg.offset == 0 {
 			// Initial load or refresh
 			m.entries = msg.result.Entries
@@ -862,49 +2282,103 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.listOffset = 0
 			m.loading = false
 		} else {
-			// Append results
-			m.entries = append(m.entries, msg.result.Entries...)
+			// Append results, skipping entries already listed: articles published since the
+			// last page shift the offset, so a page can overlap the one before it
+			m.entries = appendNewEntries(m.entries, msg.result.Entries)
 			m.totalEntries = msg.result.Total // Update total just in case
 			m.entriesOffset = msg.nextOffset
 		}
 		m.fetchingMore = false
 
+		// J reached the end of the loaded entries and was waiting for this page
+		if m.pendingNext {
+			m.pendingNext = false
+			next := m.pendingNextIndex
+			if msg.offset > 0 && next < len(m.entries) {
+				m.cursor = next
+				return m.openEntry(m.entries[next], StateBrowsing)
+			}
+		}
+
+	case searchResultsMsg:
+		m = m.recordServer(nil)
+		// Results fill the search list only, so the browse list is as it was after Esc.
+		// Replies for a term since typed over are dropped.
+		if m.state == StateSearching && m.searchMode == SearchGeneral && msg.search == m.searchInput.Value() {
+			// The server matched the term in titles and content, so show all of it
+			m.filteredEntries = msg.entries
+			m.searchCursor = 0
+		}
+
 	case contentMsg:
-		m.content = strings.Fields(msg.text)
+		m.pausedAt = time.Time{} // A pause left open on the previous article isn't time spent reading this one
+		m.content, m.emphasis = msg.words, msg.emphasis
 		m.articleLinks = msg.links
+		m.structure = msg.structure
+		m.findTerm = ""
+		m.findMatches = nil
 		m.linksCursor = 0
 		m.state = StateReading
 		m.index = 0
+		m = m.resetRhythm()
 		m.paused = true
 		m.loading = false
+		if m.positionFile != "" {
+			m = m.restorePosition()
+		}
+		if m.advancing && len(m.content) > 0 {
+			m.advancing = false
+			m.paused = false
+			return m, tick(m.currentDelay())
+		}
+		if m.cfg.AutoPlayOnOpen && len(m.content) > 0 {
+			// The first word stays up a little longer, to settle in before the rest follows
+			m.paused = false
+			return m, tick(autoPlayLeadIn + m.currentDelay())
+		}
+
+		// Show the article's length before starting it
+		if m.currentEntry != nil && !m.cfg.SkipArticleInfo {
+			m.state = StateArticleInfo
+		}
 
 	case errMsg:
+		m = m.recordServer(msg)
 		m.err = msg
 		m.loading = false
 		m.fetchingMore = false
+		m.checkingFeeds = false
 
 	case markReadMsg:
+		m = m.recordServer(msg.err)
 		if msg.err != nil {
 			m.err = msg.err
 		} else {
-			// Remove the read entry from the local list
-			newEntries := make([]*miniflux.Entry, 0, len(m.entries)-1)
-			for _, e := range m.entries {
-				if e.ID != msg.id {
-					newEntries = append(newEntries, e)
+			m = m.removeRead(msg.id)
+
+			// The cursor now rests on the entry after the one marked with M
+			if msg.id == m.markReadNextID {
+				m.markReadNextID = 0
+				if m.cfg.MarkReadOpensNext && m.state == StateBrowsing && len(m.entries) > 0 {
+					return m.openEntry(m.entries[m.cursor], StateBrowsing)
 				}
 			}
-			m.entries = newEntries
-			m.totalEntries-- // Decrement total
+		}
+		if msg.id == m.markReadNextID {
+			m.markReadNextID = 0
+		}
 
-			// Adjust cursor if necessary
-			if m.cursor >= len(m.entries) {
-				m.cursor = len(m.entries) - 1
-				m.cursor = max(m.cursor, 0)
-			}
+	case batchReadMsg:
+		m = m.recordServer(msg.err)
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			m = m.removeRead(msg.ids...)
+			m.notice = fmt.Sprintf("marked %d entries read", len(msg.ids))
 		}
 
 	case starredMsg:
+		m = m.recordServer(msg.err)
 		if msg.err != nil {
 			m.err = msg.err
 		} else {
@@ -920,9 +2394,51 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case categoriesMsg:
-		m.categories = miniflux.Categories(msg)
-		if m.state == StateSearching && m.searchMode == SearchCategory {
+	case fileLoadErrMsg:
+		m.err = fmt.Errorf("could not read %s: %w", filepath.Base(msg.path), msg.err)
+		m.loading = false
+		m.advancing = false
+		if m.playlistDir != "" {
+			m.state = StateFiles
+		}
+
+	case clipboardMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+
+		// Pasted text starts playing straight away
+		m.err = nil
+		m.currentEntry = nil
+		m.currentFile = "Clipboard"
+		m.positionFile = ""
+		m.articleLinks = nil
+		m.findTerm = ""
+		m.findMatches = nil
+		m.content = prepareWords(msg.text, m.cfg)
+		m.emphasis = nil
+		m.index = 0
+		m = m.resetRhythm()
+		m.pausedAt = time.Time{}
+		m.readingReturnState = m.state
+		m.state = StateReading
+		m.paused = false
+		return m, tick(m.currentDelay())
+
+	case connectionTestMsg:
+		m = m.recordServer(msg.err)
+		if msg.err != nil {
+			m.setupStatus = fmt.Sprintf("Connection failed: %v", msg.err)
+		} else {
+			m.setupStatus = fmt.Sprintf("Connected as %s.", msg.username)
+		}
+
+	case categoriesMsg:
+		m = m.recordServer(nil)
+		m.categories = miniflux.Categories(msg)
+		if m.state == StateSearching && m.searchMode == SearchCategory {
 			m.filteredList = nil
 			m.filteredIDs = nil
 			term := strings.ToLower(m.searchInput.Value())
@@ -936,6 +2452,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case feedsMsg:
 		m.feeds = miniflux.Feeds(msg)
+		m.checkingFeeds = false
+		m.feedsCursor = max(min(m.feedsCursor, len(m.feeds)-1), 0)
+		m = m.scrollFeeds()
 		if m.state == StateSearching && m.searchMode == SearchFeed {
 			m.filteredList = nil
 			m.filteredIDs = nil
@@ -970,24 +2489,301 @@ func (m model) View() string {
 		return m.viewHelp()
 	case StateLinks:
 		return m.viewLinks()
+	case StateSetup:
+		return m.viewSetup()
+	case StateFiles:
+		return m.viewFiles()
+	case StateDigest:
+		return m.viewDigest()
+	case StateArticleInfo:
+		return m.viewArticleInfo()
+	case StateFeedHealth:
+		return m.viewFeedHealth()
+	case StateManageFeeds:
+		return m.viewManageFeeds()
+	case StateStats:
+		return m.viewStats()
 	}
 	return m.viewReading()
 }
 
+// canGoBack reports whether the reader was opened from a list it can return to,
+// as opposed to reading a file or stdin directly
+func (m model) canGoBack() bool {
+	switch m.state {
+	case StateReading, StateYouTubeLink, StateArticleInfo:
+		return m.minifluxClient != nil || m.readingReturnState == StateFiles
+	}
+	return false
+}
+
+// advance opens the next file of the playlist after one has finished
+func (m model) advance() (tea.Model, tea.Cmd) {
+	m.playlistCursor++
+	m.advancing = true
+	return m.openPlaylistItem(m.playlistCursor)
+}
+
+// advanceTick counts down a second before auto-advance
+func advanceTick(seq int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return advanceTickMsg{seq: seq} })
+}
+
+// selectedIDs lists the selected entries still in the list, in list order
+func (m model) selectedIDs() []int64 {
+	var ids []int64
+	for _, e := range m.entries {
+		if m.selected[e.ID] {
+			ids = append(ids, e.ID)
+		}
+	}
+	return ids
+}
+
+// starSelected stars every selected entry, or unstars them all when they are all starred already,
+// then clears the selection
+func (m model) starSelected() (tea.Model, tea.Cmd) {
+	var picked []*miniflux.Entry
+	allStarred := true
+	for _, e := range m.entries {
+		if m.selected[e.ID] {
+			picked = append(picked, e)
+			allStarred = allStarred && e.Starred
+		}
+	}
+	m.selected = nil
+
+	// Starring is a toggle on the server, so only entries in the wrong state are sent
+	var cmds []tea.Cmd
+	for _, e := range picked {
+		if e.Starred == allStarred {
+			cmds = append(cmds, toggleStarred(m.minifluxClient, e.ID))
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// removeRead drops entries marked read from the list, counting only those that were in it.
+// The cursor stays on the entry it was on, or the one after if that entry went.
+func (m model) removeRead(ids ...int64) model {
+	newEntries := make([]*miniflux.Entry, 0, len(m.entries))
+	above := 0
+	for i, e := range m.entries {
+		if !slices.Contains(ids, e.ID) {
+			newEntries = append(newEntries, e)
+		} else if i < m.cursor {
+			above++
+		}
+	}
+	m.cursor -= above
+	m.totalEntries -= len(m.entries) - len(newEntries)
+	m.entries = newEntries
+	if m.currentEntry != nil && slices.Contains(ids, m.currentEntry.ID) {
+		m.currentEntry.Status = miniflux.EntryStatusRead
+	}
+
+	// Adjust cursor if necessary
+	if m.cursor >= len(m.entries) {
+		m.cursor = len(m.entries) - 1
+		m.cursor = max(m.cursor, 0)
+	}
+	m.listOffset = min(m.listOffset, m.cursor)
+	return m
+}
+
+// backToList leaves the reader for the list the article was opened from
+func (m model) backToList() (tea.Model, tea.Cmd) {
+	if m.state == StateReading {
+		m.paused = true
+	}
+
+	switch m.readingReturnState {
+	case StateSearching, StateFiles:
+		m.state = m.readingReturnState
+	default:
+		m.state = StateBrowsing
+	}
+	return m, nil
+}
+
+// openEntry starts reading a Miniflux entry, or shows the link for a YouTube video
+func (m model) openEntry(selected *miniflux.Entry, returnState int) (tea.Model, tea.Cmd) {
+	m.loading = true
+	m.currentEntry = selected // Store the selected entry
+	m.currentFile = ""
+	m.positionFile = ""
+	m.readingReturnState = returnState
+
+	// Check if it's a YouTube video
+	if isYouTubeEntry(selected) {
+		m.state = StateYouTubeLink
+		m.loading = false // No content to fetch
+		return m, nil
+	}
+
+	if cached, ok := m.contentCache.get(selected.ID); ok {
+		return m, tea.Batch(func() tea.Msg { return cached }, m.prefetchAfter(selected.ID))
+	}
+	return m, tea.Batch(fetchContent(selected.Content, m.cfg), m.prefetchAfter(selected.ID))
+}
+
+// searchDelay is how long typing must pause before the search runs on the server
+const searchDelay = 400 * time.Millisecond
+
+// runSearch sends the search box term to the server. The searchResultsMsg handler shows the
+// results in the search list, leaving the browse list alone, and drops stale replies.
+func (m model) runSearch() (tea.Model, tea.Cmd) {
+	if m.minifluxClient == nil {
+		return m, nil
+	}
+	m.searchedTerm = m.searchInput.Value()
+	fetch := fetchEntries(m.minifluxClient, m.searchedTerm, m.currentCategoryID, m.currentFeedID, 0, m.filterYouTube)
+	return m, func() tea.Msg {
+		msg := fetch()
+		if entries, ok := msg.(entriesMsg); ok {
+			return searchResultsMsg{search: entries.search, entries: entries.result.Entries}
+		}
+		return msg
+	}
+}
+
+// highlightedID is the ID of the entry under the list cursor, or 0
+func (m model) highlightedID() int64 {
+	if m.cursor < 0 || m.cursor >= len(m.entries) {
+		return 0
+	}
+	return m.entries[m.cursor].ID
+}
+
+// keyEntry is the entry the per-entry keys act on: the highlighted one in the list,
+// otherwise the one last opened, which stays set after going back to the list
+func (m model) keyEntry() *miniflux.Entry {
+	if m.state == StateBrowsing {
+		if m.cursor < 0 || m.cursor >= len(m.entries) {
+			return nil
+		}
+		return m.entries[m.cursor]
+	}
+	return m.currentEntry
+}
+
+// prefetch converts an entry's content in the background unless it is already cached
+func (m model) prefetch(entry *miniflux.Entry) tea.Cmd {
+	if isYouTubeEntry(entry) || m.contentCache.has(entry.ID) {
+		return nil
+	}
+	cfg := m.cfg
+	return func() tea.Msg {
+		return prefetchedMsg{id: entry.ID, content: convertContent(entry.Content, cfg)}
+	}
+}
+
+// prefetchAfter prefetches the entry following id in the list, the likely next article
+func (m model) prefetchAfter(id int64) tea.Cmd {
+	for i, entry := range m.entries {
+		if entry.ID == id && i+1 < len(m.entries) {
+			return m.prefetch(m.entries[i+1])
+		}
+	}
+	return nil
+}
+
+// openPlaylistItem starts loading the i-th playlist file for reading
+func (m model) openPlaylistItem(i int) (tea.Model, tea.Cmd) {
+	item := m.playlist[i]
+	m.loading = true
+	m.err = nil
+	m.currentEntry = nil
+	if m.state != StateReading {
+		m.advancing = false // Opened by hand from the list
+	}
+	m.currentFile = item.Name
+	m.positionFile, _ = filepath.Abs(item.Path)
+	m.readingReturnState = StateFiles
+	return m, loadFile(item.Path, m.cfg)
+}
+
+// feedsVisibleRows is how many feeds fit between the Manage Feeds header and footer
+func (m model) feedsVisibleRows() int {
+	return max(m.height-5, 1)
+}
+
+// scrollFeeds moves the Manage Feeds window just far enough to keep the cursor in view
+func (m model) scrollFeeds() model {
+	visible := m.feedsVisibleRows()
+	if m.feedsCursor < m.feedsOffset {
+		m.feedsOffset = m.feedsCursor
+	} else if m.feedsCursor >= m.feedsOffset+visible {
+		m.feedsOffset = m.feedsCursor - visible + 1
+	}
+	return m
+}
+
+// playlistVisibleRows is how many files fit between the playlist header and footer
+func (m model) playlistVisibleRows() int {
+	headerHeight := 3
+	footerHeight := 3
+	return max(m.height-headerHeight-footerHeight, 1)
+}
+
+// finishSetup marks the first-run wizard as done and continues to the screen it was covering
+func (m model) finishSetup() (tea.Model, tea.Cmd) {
+	m.paused = true
+	m.cfg.SetupComplete = true
+	m.cfg.WPM = m.wpm
+	saveConfig(m.cfg)
+
+	m.state = m.setupReturnState
+	if m.state == StateBrowsing && m.minifluxClient != nil {
+		m.loading = true
+		return m, m.Init()
+	}
+	return m, nil
+}
+
 func (m model) viewBrowsing() string {
+	// Wide terminals get the list on the left and a preview of the highlighted entry on the right
+	if m.width >= previewMinWidth && len(m.entries) > 0 && m.err == nil {
+		listWidth := m.width * 3 / 5
+		list := m
+		list.width = listWidth
+		return lipgloss.JoinHorizontal(lipgloss.Top, list.viewBrowsing(), m.viewPreview(m.width-listWidth))
+	}
+
 	var sb strings.Builder
 
 	headerText := "Miniflux Unread Entries"
+	if m.currentFeedID != 0 {
+		headerText += " " + glyph.Crumb + " Feed: " + m.feedTitle(m.currentFeedID)
+	} else if m.currentCategoryID != 0 {
+		headerText += " " + glyph.Crumb + " Category: " + m.categoryTitle(m.currentCategoryID)
+	}
 	if m.filterYouTube {
 		headerText += " (YouTube Only)"
 	}
-	header := lipgloss.NewStyle().Bold(true).Render(headerText)
+	if n := len(m.selectedIDs()); n > 0 {
+		headerText += fmt.Sprintf(" %s %d selected", glyph.Dot, n)
+	}
+	header := lipgloss.NewStyle().Bold(true).Render(headerText) + "  " + m.connectionStatus()
+	if m.enteringID {
+		header += "  Open entry #" + m.idInput.View() + lineStyle.Render(" (Enter: Open, Esc: Cancel)")
+	} else if m.enteringFeedURL {
+		header += "  Subscribe to " + m.feedURLInput.View() + lineStyle.Render(" (Enter: Choose Category, Esc: Cancel)")
+	} else if m.catchUpIDs != nil {
+		header += "  " + focusStyle.Render(fmt.Sprintf("Mark %s unread entries older than %d days as read? (y/n)", formatThousands(len(m.catchUpIDs)), m.cfg.CatchUpDays))
+	} else if m.jumpNumber != "" {
+		header += "  Go to entry " + m.jumpNumber + lineStyle.Render(" (Enter: Jump, Esc: Cancel)")
+	} else if m.notice != "" {
+		header += "  " + focusStyle.Render(m.notice)
+	}
 	sb.WriteString(header + "\n\n") // 3 lines used for header
 
-	// Calculate available height for the list
+	// Calculate available height for the list, in lines and then in entries
 	headerHeight := 3
-	visibleHeight := m.height - headerHeight
-	visibleHeight = max(visibleHeight, 0)
+	rowHeight := m.listRowHeight()
+	visibleLines := m.height - headerHeight
+	visibleLines = max(visibleLines, 0)
+	visibleHeight := visibleLines / rowHeight
 
 	if m.loading && len(m.entries) == 0 {
 		sb.WriteString("Loading...")
@@ -1002,13 +2798,15 @@ func (m model) viewBrowsing() string {
 
 		// Render scroll indicator for top
 		if m.listOffset > 0 {
-			sb.WriteString(normalStyle.Render(strings.Repeat(" ", 15)+"▲ (more above)") + "\n")
-			visibleHeight-- // Account for scroll indicator line
+			sb.WriteString(normalStyle.Render(strings.Repeat(" ", 15)+glyph.MoreAbove+" (more above)") + "\n")
+			visibleLines-- // Account for scroll indicator line
+			visibleHeight = visibleLines / rowHeight
 		}
 
 		// Reserve space for bottom indicator if needed
 		if len(m.entries) > m.listOffset+visibleHeight {
-			visibleHeight--
+			visibleLines--
+			visibleHeight = visibleLines / rowHeight
 		}
 
 		// Render visible entries
@@ -1016,10 +2814,17 @@ func (m model) viewBrowsing() string {
 			entry := m.entries[i]
 			cursor := " "
 			style := normalStyle
+			if color, ok := m.cfg.FeedColors[entry.FeedID]; ok {
+				style = normalStyle.Foreground(lipgloss.Color(color))
+			}
 			if m.cursor == i {
 				cursor = ">"
 				style = listSelectedStyle
 			}
+			mark := " "
+			if m.selected[entry.ID] {
+				mark = glyph.Selected
+			}
 
 			dateStr := shortDate(entry.Date)
 			// Fixed width for date column (max length of "Jan 02 '06" is 10)
@@ -1030,39 +2835,36 @@ func (m model) viewBrowsing() string {
 
 			starStr := "  "
 			if entry.Starred {
-				starStr = "★ "
+				starStr = glyph.Star + " "
 			}
 
 			// Calculate available width for title
-			// Fixed prefix width: Cursor(1) + Space(1) + Date(10) + Space(1) + Star(2) = 15
+			// Fixed prefix width: Cursor(1) + Selection Mark(1) + Date(10) + Space(1) + Star(2) = 15
 			prefixWidth := 15
+			number := ""
+			if m.cfg.ListNumbers {
+				// Right-aligned to the widest number, so titles stay in one column
+				digits := len(strconv.Itoa(len(m.entries)))
+				number = lineStyle.Render(fmt.Sprintf("%*d ", digits, i+1))
+				prefixWidth += digits + 1
+			}
 			availableWidth := m.width - prefixWidth - 1 // -1 Buffer
 			availableWidth = max(availableWidth, 10)
 
-			title := cleanTitle(entry.Title)
-			if lipgloss.Width(title) > availableWidth {
-				// Truncate
-				targetWidth := availableWidth - 1 // -1 for ellipsis
-				targetWidth = max(targetWidth, 0)
-
-				var currentWidth int
-				var sbTrunc strings.Builder
-				for _, r := range title {
-					w := lipgloss.Width(string(r))
-					if currentWidth+w > targetWidth {
-						break
-					}
-					sbTrunc.WriteRune(r)
-					currentWidth += w
-				}
-				title = sbTrunc.String() + "…"
-			}
+			title := truncateToWidth(cleanTitle(entry.Title), availableWidth)
 
 			// Use lineStyle (grey) for date
 			dateRendered := lineStyle.Render(dateStr)
 			starRendered := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render(starStr) // Gold color
 
-			sb.WriteString(fmt.Sprintf("%s %s %s%s\n", cursor, dateRendered, starRendered, style.Render(title)))
+			sb.WriteString(fmt.Sprintf("%s%s%s%s %s%s\n", cursor, mark, number, dateRendered, starRendered, style.Render(title)))
+
+			// Detailed rows add the feed and the start of the article under the title
+			if m.detailedRows {
+				feed := m.feedTitle(entry.FeedID)
+				detail := truncateToWidth(feed+" "+glyph.Dot+" "+m.excerpt(entry), availableWidth)
+				sb.WriteString(strings.Repeat(" ", prefixWidth) + lineStyle.Render(detail) + "\n")
+			}
 		}
 
 		// Render scroll indicator for bottom
@@ -1070,34 +2872,87 @@ func (m model) viewBrowsing() string {
 			if m.fetchingMore {
 				sb.WriteString(normalStyle.Render(strings.Repeat(" ", 15)+"... loading more ...") + "\n")
 			} else {
-				sb.WriteString(normalStyle.Render(strings.Repeat(" ", 15)+"▼ (more below)") + "\n")
+				sb.WriteString(normalStyle.Render(strings.Repeat(" ", 15)+glyph.MoreBelow+" (more below)") + "\n")
 			}
 		}
 	} else {
 		sb.WriteString("No entries found.")
 	}
 
-	sb.WriteString("\n\n(/: Search, y: YouTube Filter, m: Mark Read, r: Refresh)")
+	quitKeys := "q"
+	if m.cfg.EscQuitsFromList {
+		quitKeys = "q/Esc"
+	}
+	sb.WriteString("\n\n(/: Search, y: YouTube Filter, Space: Select, m/M: Mark Read, v: Read Clipboard, d: Details, i: Digest, >/<: Feed Filter, r: Refresh, ?: All Keys, " + quitKeys + ": Quit)")
 
 	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
 }
+
+// previewMinWidth is the narrowest terminal that shows the preview pane beside the list
+const previewMinWidth = 120
+
+// enclosureLines lists an entry's media attachments, numbered, with their type and size, each cut to width
+func enclosureLines(entry *miniflux.Entry, width int) []string {
+	var lines []string
+	for i, enc := range entry.Enclosures {
+		line := fmt.Sprintf("%d. %s", i+1, enc.MimeType)
+		if enc.MimeType == "" {
+			line = fmt.Sprintf("%d. unknown type", i+1)
+		}
+		if enc.Size > 0 {
+			line += fmt.Sprintf(" (%.1f MB)", float64(enc.Size)/(1024*1024))
+		}
+		lines = append(lines, truncateToWidth(line+" "+enc.URL, max(width, 10)))
+	}
+	return lines
+}
+
+// viewPreview renders the highlighted entry's title, feed and opening text in a column of the given width
+func (m model) viewPreview(width int) string {
+	entry := m.entries[m.cursor]
+	textWidth := max(width-3, 10) // Border and padding
+
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().Bold(true).Width(textWidth).Render(cleanTitle(entry.Title)) + "\n")
+	sb.WriteString(lineStyle.Width(textWidth).Render(m.feedTitle(entry.FeedID)+" "+glyph.Dot+" "+shortDate(entry.Date)) + "\n\n")
+	sb.WriteString(normalStyle.Width(textWidth).Render(m.excerpt(entry)))
+	if media := enclosureLines(entry, textWidth); len(media) > 0 {
+		sb.WriteString("\n\n" + lineStyle.Width(textWidth).Render("Media (a: Play first):\n"+strings.Join(media, "\n")))
+	}
+
+	return appStyle.
+		Width(width-1).
+		Height(m.height).
+		MaxHeight(m.height).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(lineStyle.GetForeground()).
+		PaddingLeft(1).
+		Render(sb.String())
+}
+
 func (m model) viewSearching() string {
 	var sb strings.Builder
 
 	modeStr := searchModes[m.searchMode]
 	header := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Search Articles (%s)", modeStr))
+	if m.subscribeURL != "" {
+		header = lipgloss.NewStyle().Bold(true).Render("Subscribe: Choose a Category") + "  " + lineStyle.Render(truncateToWidth(m.subscribeURL, max(m.width-32, 10)))
+	} else if m.movingFeed != nil {
+		header = lipgloss.NewStyle().Bold(true).Render("Move Feed: Choose a Category") + "  " + lineStyle.Render(truncateToWidth(cleanTitle(m.movingFeed.Title), max(m.width-32, 10)))
+	}
 	sb.WriteString(header + "\n\n")
 
 	sb.WriteString(m.searchInput.View())
 	sb.WriteString("\n")
 
+	// Header, input and spacing take 5 lines, plus the footer, which wraps on narrow terminals
+	footer := "(Enter to search/select, Tab to change mode, f: Star, r: Refresh, Esc to cancel)"
+	availableHeight := max(m.height-5-lipgloss.Height(lipgloss.NewStyle().Width(m.width).Render(footer)), 1)
+
 	// Render list based on search mode
 	if m.searchMode == SearchGeneral {
 		// Show filtered entries with stars
 		sb.WriteString("\n")
-		headerHeight := 6
-		availableHeight := m.height - headerHeight
-		availableHeight = max(availableHeight, 5)
 
 		if len(m.filteredEntries) == 0 {
 			if m.searchInput.Value() != "" {
@@ -1106,16 +2961,7 @@ func (m model) viewSearching() string {
 				sb.WriteString(normalStyle.Render("Type to search articles...") + "\n")
 			}
 		} else {
-			start := 0
-			end := len(m.filteredEntries)
-
-			if m.searchCursor >= availableHeight {
-				start = m.searchCursor - availableHeight + 1
-			}
-			if end > start+availableHeight {
-				end = start + availableHeight
-			}
-
+			start, end := scrollWindow(m.searchCursor, len(m.filteredEntries), availableHeight)
 			for i := start; i < end; i++ {
 				entry := m.filteredEntries[i]
 				cursor := " "
@@ -1128,7 +2974,7 @@ func (m model) viewSearching() string {
 				// Star indicator
 				starStr := "  "
 				if entry.Starred {
-					starStr = "★ "
+					starStr = glyph.Star + " "
 				}
 				starRendered := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render(starStr)
 
@@ -1137,43 +2983,16 @@ func (m model) viewSearching() string {
 				prefixWidth := 5 // cursor + space + star
 				availableWidth := m.width - prefixWidth - 1
 				availableWidth = max(availableWidth, 10)
-				if lipgloss.Width(title) > availableWidth {
-					targetWidth := availableWidth - 1
-					targetWidth = max(targetWidth, 0)
-					var currentWidth int
-					var sbTrunc strings.Builder
-					for _, r := range title {
-						w := lipgloss.Width(string(r))
-						if currentWidth+w > targetWidth {
-							break
-						}
-						sbTrunc.WriteRune(r)
-						currentWidth += w
-					}
-					title = sbTrunc.String() + "…"
-				}
+				title = truncateToWidth(title, availableWidth)
 
 				sb.WriteString(fmt.Sprintf("%s %s%s\n", cursor, starRendered, style.Render(title)))
 			}
 		}
 	} else if m.searchMode == SearchCategory || m.searchMode == SearchFeed {
 		sb.WriteString("\n")
-		// Calculate available height
-		headerHeight := 6 // Header + Input + Spacing
-		availableHeight := m.height - headerHeight
-		availableHeight = max(availableHeight, 5)
-
-		start := 0
-		end := len(m.filteredList)
-
-		// Simple scrolling logic for search view
-		if m.searchCursor >= availableHeight {
-			start = m.searchCursor - availableHeight + 1
-		}
-		if end > start+availableHeight {
-			end = start + availableHeight
-		}
 
+		// Each name is cut to one line, as a wrapped one would push the list past the screen
+		start, end := scrollWindow(m.searchCursor, len(m.filteredList), availableHeight)
 		for i := start; i < end; i++ {
 			cursor := " "
 			style := normalStyle
@@ -1181,15 +3000,39 @@ func (m model) viewSearching() string {
 				cursor = ">"
 				style = listSelectedStyle
 			}
-			sb.WriteString(fmt.Sprintf("%s %s\n", cursor, style.Render(m.filteredList[i])))
+			name := truncateToWidth(cleanTitle(m.filteredList[i]), max(m.width-3, 10))
+			sb.WriteString(fmt.Sprintf("%s %s\n", cursor, style.Render(name)))
 		}
 	}
 
-	sb.WriteString("\n(Enter to search/select, Tab to change mode, f: Star, r: Refresh, Esc to cancel)")
+	sb.WriteString("\n" + footer)
 
 	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
 }
 
+// jumpToEntry puts the cursor on the nth entry (1-based, clamped to the list), scrolling it into view
+func (m model) jumpToEntry(n int) model {
+	if len(m.entries) == 0 {
+		return m
+	}
+	m.cursor = min(max(n, 1), len(m.entries)) - 1
+
+	rows := max((m.height-5)/m.listRowHeight(), 1) // Header and both scroll indicators
+	if m.cursor < m.listOffset || m.cursor >= m.listOffset+rows {
+		m.listOffset = max(m.cursor-rows/2, 0)
+	}
+	return m
+}
+
+// scrollWindow is the range [start, end) of a list of total rows to show in height rows so the
+// cursor row is on screen, even when the cursor is left past the end of a list that has shrunk
+func scrollWindow(cursor, total, height int) (start, end int) {
+	height = max(height, 1)
+	cursor = max(min(cursor, total-1), 0)
+	start = max(cursor-height+1, 0)
+	return start, min(start+height, total)
+}
+
 func (m model) viewYouTubeLink() string {
 	if m.currentEntry == nil {
 		return appStyle.Width(m.width).Height(m.height).Render("No YouTube link selected. (Esc to go back)")
@@ -1198,13 +3041,50 @@ func (m model) viewYouTubeLink() string {
 	var sb strings.Builder
 
 	sb.WriteString(lipgloss.NewStyle().Bold(true).Render("YouTube Video Link") + "\n\n")
-	sb.WriteString(fmt.Sprintf("Title: %s\n\n", m.currentEntry.Title))
-	sb.WriteString(fmt.Sprintf("URL: %s\n\n", m.currentEntry.URL))
+	sb.WriteString(m.wrapDetail("Title: "+m.currentEntry.Title) + "\n\n")
+	sb.WriteString(m.wrapDetail("URL: "+m.currentEntry.URL) + "\n\n")
 	sb.WriteString(lipgloss.NewStyle().Faint(true).Render("(Press Esc to go back to list)"))
 
 	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
 }
 
+// wrapDetail wraps a detail view line to the terminal width, breaking long URLs if need be
+func (m model) wrapDetail(s string) string {
+	return lipgloss.NewStyle().Width(max(m.width, 20)).Render(s)
+}
+
+func (m model) viewArticleInfo() string {
+	var sb strings.Builder
+
+	sb.WriteString(lipgloss.NewStyle().Bold(true).Render("Ready to Read") + "\n\n")
+	sb.WriteString(m.wrapDetail("Title: "+cleanTitle(m.currentEntry.Title)) + "\n")
+	sb.WriteString(m.wrapDetail("Feed: "+m.feedTitle(m.currentEntry.FeedID)) + "\n\n")
+
+	words := len(m.content)
+	seconds := words * 60 / max(m.wpm, 1)
+	sb.WriteString(fmt.Sprintf("Words: %s\n", formatThousands(words)))
+	sb.WriteString(fmt.Sprintf("Time:  about %d:%02d at %d WPM\n", seconds/60, seconds%60, m.wpm))
+	if len(m.articleLinks) > 0 {
+		sb.WriteString(fmt.Sprintf("Links: %d\n", len(m.articleLinks)))
+	}
+	if s := m.structure; s.sentences > 0 {
+		sb.WriteString(m.wrapDetail(fmt.Sprintf("Shape: %s sentences in %s paragraphs, longest word %q", formatThousands(s.sentences), formatThousands(s.paragraphs), s.longestWord)) + "\n")
+	}
+
+	help := "(Space/Enter: Start reading, o: Open in browser, Esc: Back)"
+	if media := enclosureLines(m.currentEntry, m.width-4); len(media) > 0 {
+		sb.WriteString("\nMedia:\n" + strings.Join(media, "\n") + "\n")
+		help = "(Space/Enter: Start reading, 1-9: Play media, o: Open in browser, Esc: Back)"
+	}
+	if m.notice != "" {
+		sb.WriteString("\n" + focusStyle.Render(m.notice) + "\n")
+	}
+
+	sb.WriteString("\n" + lipgloss.NewStyle().Faint(true).Render(help))
+
+	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
+}
+
 func (m model) viewLogin() string {
 	var sb strings.Builder
 
@@ -1242,24 +3122,51 @@ func (m model) viewHelp() string {
 	}{
 		{"Space", "Pause / Resume Reading"},
 		{"k / j", "Increase / Decrease WPM"},
-		{"Left / Right", "Rewind / Fast Forward (10 words)"},
-		{"g / G", "Jump to Start / End"},
-		{"s", "Toggle Large Text Size"},
+		{"Left / Right", "Rewind / Fast Forward (10 words, faster when held with seek_acceleration)"},
+		{"g / G", "Jump to Start / End, Still Playing"},
+		{"R", "Restart the Article From the Top, Paused"},
+		{"e", "Edit Article Text in $EDITOR"},
+		{"w", "Save the Article's Text to a File in SaveDir"},
+		{"J / K", "Next / Previous Article"},
+		{"/ n N", "Reader: Find in Article, Next / Previous Match"},
+		{"p", "Toggle Quick Actions When Paused"},
+		{"s", "Reader: cycle text size (normal, wide, block letters) | Playlist: sort by name/date"},
 		{"r", "Reader: toggle ramping | Lists: refresh"},
 		{"z", "Toggle Zen Mode"},
+		{"b", "Toggle Bionic Style (Bold Word Start Instead of a Red Focus Letter)"},
 		{"l", "Show Article Links"},
-		{"c", "Cycle Themes"},
+		{"c / C", "Cycle Themes Forward / Backward"},
 		{"/", "Search Articles (Miniflux)"},
 		{"j / k", "Navigate Article List"},
 		{"Enter", "Select Article"},
 		{"o", "Open Article in Browser"},
-		{"f", "Toggle Starred (Browse & Search)"},
-		{"m", "Mark as Read"},
+		{"W", "Open the Entry in the Miniflux Web UI"},
+		{"a", "Play the Entry's First Media Attachment (Podcast, Video)"},
+		{"!", "Log the Entry as Broken, to speedreader-problems.log Next to the Config"},
+		{"f", "Toggle Starred (Browse & Search; Stars or Unstars the Selected Entries Together)"},
+		{"PgUp/PgDn", "Page Through the List"},
+		{"m", "Mark as Read (the Selected Entries, if Any)"},
+		{"M", "Mark as Read and Go to Next"},
 		{"y", "Filter YouTube Videos"},
+		{"v", "Read Clipboard Contents"},
+		{"i", "Unread Digest by Feed"},
+		{"H", "Feed Health: Feeds With Fetch Errors"},
+		{"A", "Playlist: Toggle Auto-Advance for This Session"},
+		{"S", "Reading Statistics Dashboard (also: speedreader stats --dashboard)"},
+		{"d", "Toggle Detailed Rows (feed and excerpt)"},
+		{">", "Show Only the Selected Entry's Feed"},
+		{"<", "Clear Feed/Category Filter"},
+		{"\\", "Clear All Filters and Search, Showing All Unread"},
+		{"#", "Open an Entry by Its ID"},
+		{"0-9 Enter", "Jump to the Entry With That Number in the List (Esc Cancels)"},
+		{"+", "Subscribe to a Feed by URL, Then Choose Its Category"},
+		{"F", "Manage Feeds: Move Them to Another Category or Rename Them"},
+		{"Space", "Select or Unselect the Entry for m and f (Esc Clears the Selection)"},
+		{"X", "Catch Up: Mark All Unread Older Than CatchUpDays as Read, After Confirming"},
 		{"r", "Refresh latest entries"},
-		{"Esc", "Back / Quit"},
+		{"Esc", "Back / Quit (Quits From the Entry List Unless EscQuitsFromList Is Off)"},
 		{"?", "Show this Help"},
-		{"q", "Quit Application"},
+		{"q", "Quit Application (or Back with q_goes_back)"},
 	}
 
 	// Calculate max key width for alignment
@@ -1281,77 +3188,379 @@ func (m model) viewHelp() string {
 	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
 }
 
-func (m model) viewLinks() string {
+func (m model) viewFiles() string {
 	var sb strings.Builder
 
-	// Header
-	title := "Article Links"
-	if m.currentEntry != nil {
-		title = fmt.Sprintf("Links in: %s", m.currentEntry.Title)
-		// Truncate title if too long
-		if len(title) > m.width-4 {
-			title = title[:m.width-7] + "..."
+	sortName := "name"
+	if m.cfg.PlaylistSort == PlaylistSortMTime {
+		sortName = "date"
+	}
+	header := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Playlist: %s (by %s)", m.playlistDir, sortName))
+	sb.WriteString(header + "\n\n")
+
+	if m.err != nil {
+		sb.WriteString(fmt.Sprintf("Error: %v\n\n", m.err))
+	}
+
+	if m.loading {
+		sb.WriteString("Loading...\n")
+	} else if len(m.playlist) == 0 {
+		sb.WriteString("No readable files (.txt, .md, .html) in this directory.\n")
+	} else {
+		visible := m.playlistVisibleRows()
+		for i := m.playlistOffset; i < m.playlistOffset+visible && i < len(m.playlist); i++ {
+			item := m.playlist[i]
+			cursor := " "
+			style := normalStyle
+			if i == m.playlistCursor {
+				cursor = ">"
+				style = listSelectedStyle
+			}
+
+			dateStr := shortDate(item.ModTime)
+			dateWidth := 10
+			if len(dateStr) < dateWidth {
+				dateStr = dateStr + strings.Repeat(" ", dateWidth-len(dateStr))
+			}
+			sb.WriteString(fmt.Sprintf("%s %s %s\n", cursor, lineStyle.Render(dateStr), style.Render(item.Name)))
 		}
 	}
-	sb.WriteString(lipgloss.NewStyle().Bold(true).Render(title) + "\n\n")
 
-	if len(m.articleLinks) == 0 {
-		sb.WriteString("No links found in this article.\n")
-		sb.WriteString("\n" + lipgloss.NewStyle().Faint(true).Render("Press Esc or l to return to reading."))
-		return appStyle.Width(m.width).Height(m.height).Render(sb.String())
+	autoAdvance := "off"
+	if m.autoAdvance {
+		autoAdvance = "on"
 	}
+	sb.WriteString(fmt.Sprintf("\n(Enter: Read, s: Sort by name/date, r: Rescan, A: Auto-advance (%s))", autoAdvance))
 
-	// Calculate available lines and number of visible items (each link = 2 lines)
-	headerHeight := 4 // Header + blank line + footer
-	footerHeight := 2
-	availableLines := m.height - headerHeight - footerHeight
+	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
+}
 
-	listOffset := m.linksListOffset
+func (m model) viewDigest() string {
+	var sb strings.Builder
 
-	// Render scroll indicator for top
-	if listOffset > 0 {
-		sb.WriteString(normalStyle.Render("  ▲ (more above)") + "\n")
-		availableLines--
+	header := lipgloss.NewStyle().Bold(true).Render("Unread Digest")
+	sb.WriteString(header + "\n\n")
+
+	digest := digestByFeed(m.entries)
+	if len(digest) == 0 {
+		sb.WriteString("No unread entries.\n")
+	} else {
+		// The digest only covers pages fetched so far, so say so when there are more on the server
+		summary := fmt.Sprintf("%d unread in %d feeds", len(m.entries), len(digest))
+		if m.totalEntries > len(m.entries) {
+			summary = fmt.Sprintf("%d of %d unread loaded, in %d feeds", len(m.entries), m.totalEntries, len(digest))
+		}
+		sb.WriteString(hudStyle.Render(summary) + "\n\n")
+
+		countWidth := 5
+		dateWidth := 10
+		titleWidth := max(m.width-countWidth-dateWidth-4, 10)
+		sb.WriteString(lineStyle.Render(fmt.Sprintf("%*s  %-*s  %s", countWidth, "Count", dateWidth, "Oldest", "Feed")) + "\n")
+
+		// Header, summary, column titles and footer take 7 lines
+		visible := max(m.height-7, 1)
+		for i := m.digestOffset; i < m.digestOffset+visible && i < len(digest); i++ {
+			d := digest[i]
+			title := truncateToWidth(d.Title, titleWidth)
+			sb.WriteString(fmt.Sprintf("%*d  %s  %s\n", countWidth, d.Count, lineStyle.Render(fmt.Sprintf("%-*s", dateWidth, shortDate(d.Oldest))), normalStyle.Render(title)))
+		}
 	}
 
-	// Check if we need bottom indicator
-	visibleItems := availableLines / 2
-	visibleItems = max(visibleItems, 1)
+	sb.WriteString("\n(j/k: Scroll, i/Esc: Back to list)")
 
-	needsBottomIndicator := listOffset+visibleItems < len(m.articleLinks)
-	if needsBottomIndicator {
-		availableLines--
-		visibleItems = availableLines / 2
-		visibleItems = max(visibleItems, 1)
+	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
+}
+
+// leaveStats goes back to the list from the statistics dashboard, or quits when it was opened on its own
+func (m model) leaveStats() (tea.Model, tea.Cmd) {
+	if m.minifluxClient == nil {
+		return m, tea.Quit
 	}
+	m.state = StateBrowsing
+	return m, nil
+}
 
-	// Render visible links
-	for i := listOffset; i < listOffset+visibleItems && i < len(m.articleLinks); i++ {
-		link := m.articleLinks[i]
-		cursor := " "
-		style := normalStyle
-		if m.linksCursor == i {
-			cursor = ">"
-			style = listSelectedStyle
-		}
+// statsSparklineDays is how many days the dashboard's word count sparkline covers
+const statsSparklineDays = 14
 
-		// Format link text and URL
-		linkText := link.Text
-		linkURL := link.URL
+// viewStats shows all-time, today's and recent reading statistics, including this session so far
+func (m model) viewStats() string {
+	var sb strings.Builder
+	sb.WriteString(lipgloss.NewStyle().Bold(true).Render("Reading Statistics") + "\n\n")
 
-		// Calculate available width
-		numStr := fmt.Sprintf("%d.", i+1)
-		prefixWidth := len(numStr) + 3
+	now := time.Now()
+	days := withSession(m.cfg.DailyStats, now, m.sessionArticles, m.sessionWords)
+	today := dayStatsFor(days, now)
 
-		availableWidth := m.width - prefixWidth - 1
-		availableWidth = max(availableWidth, 20)
+	rows := [][2]string{
+		{"All time", fmt.Sprintf("%s articles %s %s words", formatThousands(m.cfg.TotalArticles+m.sessionArticles), glyph.Dot, formatThousands(m.cfg.TotalWords+m.sessionWords))},
+		{"Today", fmt.Sprintf("%s articles %s %s words", formatThousands(today.Articles), glyph.Dot, formatThousands(today.Words))},
+		{"Streak", fmt.Sprintf("%d days", readingStreak(days, now))},
+	}
+	if m.cfg.HighestTier > 0 {
+		rows = append(rows, [2]string{"Badge", fmt.Sprintf("Tier %d (%s+ words)", m.cfg.HighestTier, formatThousands(tierThresholds[m.cfg.HighestTier-1]))})
+	}
+	if trend := wpmTrend(m.cfg.WPMHistory); trend != "" {
+		rows = append(rows, [2]string{"Speed", trend})
+	}
 
-		// Truncate text if needed
-		if len(linkText) > availableWidth/2 {
-			linkText = linkText[:availableWidth/2-3] + "..."
+	words := make([]int, statsSparklineDays)
+	for i := range words {
+		day := now.AddDate(0, 0, i-statsSparklineDays+1)
+		words[i] = dayStatsFor(days, day).Words
+	}
+	rows = append(rows, [2]string{fmt.Sprintf("Last %d days", statsSparklineDays), focusStyle.Render(sparkline(words)) + lineStyle.Render(fmt.Sprintf("  max %s words/day", formatThousands(slices.Max(words))))})
+
+	var body strings.Builder
+	for i, row := range rows {
+		if i > 0 {
+			body.WriteString("\n")
 		}
+		body.WriteString(lineStyle.Render(fmt.Sprintf("%-14s", row[0])) + normalStyle.Render(row[1]))
+	}
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lineStyle.GetForeground()).
+		Padding(0, 1).
+		Render(body.String())
+	sb.WriteString(box + "\n")
+
+	sb.WriteString("\n(S/Esc: Back)")
+	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
+}
 
-		// Format: [num] text
+// sparkline draws values as a row of block characters scaled to the largest one
+func sparkline(values []int) string {
+	bars := glyph.Bars
+	top := max(slices.Max(values), 1)
+	var sb strings.Builder
+	for _, v := range values {
+		if v == 0 {
+			sb.WriteRune(' ')
+			continue
+		}
+		sb.WriteRune(bars[min(v*len(bars)/(top+1), len(bars)-1)])
+	}
+	return sb.String()
+}
+
+// viewFeedHealth lists the feeds Miniflux is failing to fetch or parse, with their last error
+func (m model) viewFeedHealth() string {
+	var sb strings.Builder
+
+	header := lipgloss.NewStyle().Bold(true).Render("Feed Health")
+	sb.WriteString(header + "\n\n")
+
+	broken := brokenFeeds(m.feeds)
+	switch {
+	case m.checkingFeeds:
+		sb.WriteString("Checking feeds...\n")
+	case m.err != nil:
+		sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(m.err.Error()) + "\n")
+	case len(broken) == 0:
+		sb.WriteString(fmt.Sprintf("All %d feeds are fetching without errors.\n", len(m.feeds)))
+	default:
+		sb.WriteString(hudStyle.Render(fmt.Sprintf("%d of %d feeds have errors", len(broken), len(m.feeds))) + "\n\n")
+
+		// Header, summary and footer take 6 lines; each feed takes 3
+		visible := max((m.height-6)/3, 1)
+		textWidth := max(m.width-2, 10)
+		for i := m.healthOffset; i < m.healthOffset+visible && i < len(broken); i++ {
+			f := broken[i]
+			status := fmt.Sprintf("%d errors, checked %s", f.ParsingErrorCount, shortDate(f.CheckedAt))
+			sb.WriteString(normalStyle.Render(truncateToWidth(cleanTitle(f.Title), textWidth)) + "  " + lineStyle.Render(status) + "\n")
+			message := f.ParsingErrorMsg
+			if message == "" {
+				message = "(no error message)"
+			}
+			sb.WriteString("  " + focusStyle.Render(truncateToWidth(cleanTitle(message), textWidth-2)) + "\n\n")
+		}
+	}
+
+	sb.WriteString("\n(j/k: Scroll, r: Check again, H/Esc: Back to list)")
+
+	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
+}
+
+// viewManageFeeds lists the feeds with their categories, for moving and renaming them
+func (m model) viewManageFeeds() string {
+	var sb strings.Builder
+
+	header := lipgloss.NewStyle().Bold(true).Render("Manage Feeds")
+	if m.notice != "" {
+		header += "  " + focusStyle.Render(m.notice)
+	}
+	sb.WriteString(header + "\n\n")
+
+	switch {
+	case m.checkingFeeds && len(m.feeds) == 0:
+		sb.WriteString("Loading feeds...\n")
+	case m.err != nil:
+		sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(m.err.Error()) + "\n")
+	case len(m.feeds) == 0:
+		sb.WriteString("No feeds yet. Press + in the list to subscribe to one.\n")
+	default:
+		visible := m.feedsVisibleRows()
+		textWidth := max(m.width-4, 10)
+		for i := m.feedsOffset; i < m.feedsOffset+visible && i < len(m.feeds); i++ {
+			f := m.feeds[i]
+			cursor := " "
+			style := normalStyle
+			if i == m.feedsCursor {
+				cursor = ">"
+				style = listSelectedStyle
+			}
+			category := ""
+			if f.Category != nil {
+				category = "  " + f.Category.Title
+			}
+			title := truncateToWidth(cleanTitle(f.Title), max(textWidth-lipgloss.Width(category), 10))
+			sb.WriteString(cursor + " " + style.Render(title) + lineStyle.Render(category) + "\n")
+		}
+	}
+
+	if m.renamingFeed {
+		sb.WriteString("\nRename to " + m.feedTitleInput.View() + lineStyle.Render(" (Enter: Save, Esc: Cancel)"))
+	} else {
+		sb.WriteString("\n(j/k: Move, m: Move to Category, n: Rename, r: Refresh, F/Esc: Back to list)")
+	}
+
+	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
+}
+
+// brokenFeeds returns the feeds with fetch or parse errors, those failing most often first
+func brokenFeeds(feeds miniflux.Feeds) []*miniflux.Feed {
+	var broken []*miniflux.Feed
+	for _, f := range feeds {
+		if f.ParsingErrorCount > 0 || f.ParsingErrorMsg != "" {
+			broken = append(broken, f)
+		}
+	}
+	sort.SliceStable(broken, func(i, j int) bool {
+		return broken[i].ParsingErrorCount > broken[j].ParsingErrorCount
+	})
+	return broken
+}
+
+func (m model) viewSetup() string {
+	var sb strings.Builder
+
+	sb.WriteString(lipgloss.NewStyle().Bold(true).Render("Welcome to Speedreader") + "\n\n")
+
+	switch m.setupStep {
+	case setupStepWelcome:
+		sb.WriteString("There are two ways to use Speedreader:\n\n")
+		sb.WriteString(focusStyle.Render("1. Local files") + "\n")
+		sb.WriteString("   Run `speedreader <file>` or pipe text in, e.g. `cat notes.txt | speedreader`.\n\n")
+		sb.WriteString(focusStyle.Render("2. Miniflux") + "\n")
+		sb.WriteString("   Start without a file to browse your unread Miniflux entries.\n")
+		sb.WriteString("   You'll need your server URL and an API token (Settings > API Keys).\n\n")
+
+		if m.minifluxClient != nil {
+			sb.WriteString("Miniflux credentials were found. Press t to test the connection.\n\n")
+		} else {
+			sb.WriteString("No Miniflux credentials were found. Without a file, the login screen follows setup.\n\n")
+		}
+		if m.setupStatus != "" {
+			sb.WriteString(m.setupStatus + "\n\n")
+		}
+
+		sb.WriteString(lipgloss.NewStyle().Faint(true).Render("(Enter: Continue to speed calibration, Esc: Skip setup)"))
+
+	case setupStepCalibrate:
+		sb.WriteString("Let's pick a starting speed. Play the sample and adjust until it feels comfortable.\n\n")
+
+		left, focus, right := calculateORP(m.calibrationWords[m.calibrationIndex], m.cfg.ORPMode)
+		padLen := m.width/2 - lipgloss.Width(left)
+		padLen = max(padLen, 0)
+		sb.WriteString(strings.Repeat(" ", padLen) + normalStyle.Render(left) + focusStyle.Render(focus) + normalStyle.Render(right) + "\n\n")
+
+		status := "PLAYING"
+		if m.paused {
+			status = "PAUSED"
+		}
+		sb.WriteString(hudStyle.Width(m.width).Render(fmt.Sprintf("WPM: %d | %s", m.wpm, status)) + "\n\n")
+		if m.notice != "" {
+			sb.WriteString(focusStyle.Render(m.notice) + "\n\n")
+		}
+
+		sb.WriteString(lipgloss.NewStyle().Faint(true).Render("(Space: Play/Pause, k/j: Faster/Slower, Enter: Keep this speed, Esc: Skip)"))
+	}
+
+	return appStyle.Width(m.width).Height(m.height).Render(sb.String())
+}
+
+func (m model) viewLinks() string {
+	var sb strings.Builder
+
+	// Header
+	title := "Article Links"
+	if m.currentEntry != nil {
+		title = fmt.Sprintf("Links in: %s", m.currentEntry.Title)
+		// Truncate title if too long
+		if len(title) > m.width-4 {
+			title = title[:m.width-7] + "..."
+		}
+	}
+	sb.WriteString(lipgloss.NewStyle().Bold(true).Render(title) + "\n\n")
+
+	if len(m.articleLinks) == 0 {
+		sb.WriteString("No links found in this article.\n")
+		sb.WriteString("\n" + lipgloss.NewStyle().Faint(true).Render("Press Esc or l to return to reading."))
+		return appStyle.Width(m.width).Height(m.height).Render(sb.String())
+	}
+
+	// Calculate available lines and number of visible items (each link = 2 lines)
+	headerHeight := 4 // Header + blank line + footer
+	footerHeight := 2
+	availableLines := m.height - headerHeight - footerHeight
+
+	listOffset := m.linksListOffset
+
+	// Render scroll indicator for top
+	if listOffset > 0 {
+		sb.WriteString(normalStyle.Render("  "+glyph.MoreAbove+" (more above)") + "\n")
+		availableLines--
+	}
+
+	// Check if we need bottom indicator
+	visibleItems := availableLines / 2
+	visibleItems = max(visibleItems, 1)
+
+	needsBottomIndicator := listOffset+visibleItems < len(m.articleLinks)
+	if needsBottomIndicator {
+		availableLines--
+		visibleItems = availableLines / 2
+		visibleItems = max(visibleItems, 1)
+	}
+
+	// Render visible links
+	for i := listOffset; i < listOffset+visibleItems && i < len(m.articleLinks); i++ {
+		link := m.articleLinks[i]
+		cursor := " "
+		style := normalStyle
+		if m.linksCursor == i {
+			cursor = ">"
+			style = listSelectedStyle
+		}
+
+		// Format link text and URL
+		linkText := link.Text
+		linkURL := link.URL
+
+		// Calculate available width
+		numStr := fmt.Sprintf("%d.", i+1)
+		prefixWidth := len(numStr) + 3
+
+		availableWidth := m.width - prefixWidth - 1
+		availableWidth = max(availableWidth, 20)
+
+		// Truncate text if needed
+		if len(linkText) > availableWidth/2 {
+			linkText = linkText[:availableWidth/2-3] + "..."
+		}
+
+		// Format: [num] text
 		//         URL (dimmed)
 		numRendered := lineStyle.Render(numStr)
 		sb.WriteString(fmt.Sprintf("%s %s %s\n", cursor, numRendered, style.Render(linkText)))
@@ -1367,7 +3576,7 @@ func (m model) viewLinks() string {
 
 	// Render scroll indicator for bottom
 	if needsBottomIndicator {
-		sb.WriteString(normalStyle.Render("  ▼ (more below)") + "\n")
+		sb.WriteString(normalStyle.Render("  "+glyph.MoreBelow+" (more below)") + "\n")
 	}
 
 	// Footer
@@ -1387,52 +3596,66 @@ func (m model) viewReading() string {
 	}
 
 	if len(m.content) == 0 {
+		if m.err != nil {
+			return fmt.Sprintf("Error: %v\n\nPress q to quit.", m.err)
+		}
+		if m.loading && m.loadPath != "" {
+			return fmt.Sprintf("Loading %s%s", filepath.Base(m.loadPath), glyph.Ellipsis)
+		}
 		return "No readable content available."
 	}
 
 	// Helper for full-width background lines
 	blankLine := normalStyle.Render(strings.Repeat(" ", m.width))
 
-	// 1. Prepare Content Line (Left + Focus + Right)
+	// 1. Prepare Content Lines (the current word, plus its neighbours in the stacked layout)
 	word := m.content[m.index]
-	left, focus, right := calculateORP(word)
-
-	if m.largeText {
-		left = toFullWidth(left)
-		focus = toFullWidth(focus)
-		right = toFullWidth(right)
+	if m.punctBeat {
+		word = sentenceEnding(word)
+	}
+	contentLines := []string{m.renderWordLine(word)}
+	if m.textSize == TextBlock {
+		if lines, ok := m.renderBlockWord(word); ok {
+			contentLines = lines
+		}
+	}
+	if m.cfg.ReadingLines == 3 && !m.zenMode {
+		prev, next := "", ""
+		if m.index > 0 {
+			prev = m.content[m.index-1]
+		}
+		if m.index < len(m.content)-1 {
+			next = m.content[m.index+1]
+		}
+		contentLines = append(append([]string{m.renderContextLine(prev, -1)}, contentLines...), m.renderContextLine(next, 1))
+	}
+	if m.glossActive() && !m.zenMode {
+		// The line is kept even before the gloss arrives, so the word doesn't jump
+		gloss, _ := m.glosses.get(glossKey(m.content[m.index]))
+		contentLines = append(contentLines, hudStyle.Width(m.width).Align(lipgloss.Center).Render(truncateToWidth(gloss, m.width)))
 	}
-
-	// ORP Alignment Logic
-	centerX := m.width / 2
-
-	leftStr := normalStyle.Render(left)
-	focusStr := focusStyle.Render(focus)
-	rightStr := normalStyle.Render(right)
-
-	// Left Padding
-	leftLen := lipgloss.Width(left) // Width of the characters
-	padLen := centerX - leftLen
-	padLen = max(padLen, 0)
-	leftPadding := normalStyle.Render(strings.Repeat(" ", padLen))
-
-	// Right Padding
-	currentContentWidth := lipgloss.Width(leftPadding) + lipgloss.Width(leftStr) + lipgloss.Width(focusStr) + lipgloss.Width(rightStr)
-	rightPadLen := m.width - currentContentWidth
-	rightPadLen = max(rightPadLen, 0)
-	rightPadding := normalStyle.Render(strings.Repeat(" ", rightPadLen))
-
-	contentLine := leftPadding + leftStr + focusStr + rightStr + rightPadding
 
 	// 2. Prepare Separators & Gaps
-	separator := lineStyle.Render(strings.Repeat("─", m.width))
+	separator := lineStyle.Render(m.separatorLine())
+	if m.pulsing {
+		separator = focusStyle.Render(m.separatorLine())
+	}
 
 	// 3. Prepare HUD
 	progressBar := m.renderProgressBar()
 	timeRemaining := m.renderTimeRemaining()
 	wpmStr := fmt.Sprintf("WPM: %d", m.wpm)
+	if m.notice != "" {
+		wpmStr += " (" + m.notice + ")"
+	}
 	status := "PLAYING"
-	if m.paused {
+	if m.advanceIn > 0 {
+		status = fmt.Sprintf("Next: %s in %ds%s (Esc to stop)", m.playlist[m.playlistCursor+1].Name, m.advanceIn, glyph.Ellipsis)
+	} else if m.reflecting {
+		status = "Paused for reflection " + glyph.Dash + " Space to continue"
+	} else if m.finished {
+		status = "FINISHED (g: Read Again)"
+	} else if m.paused {
 		status = "PAUSED (Press Space)"
 	}
 
@@ -1441,14 +3664,34 @@ func (m model) viewReading() string {
 		rampStatus = "ON"
 	}
 
-	hudText := fmt.Sprintf("%s | %s\n%s\n%s | Size: s | Color: c | Ramp: r (%s) | Zen: z", wpmStr, timeRemaining, progressBar, status, rampStatus)
+	if modes := m.modeIndicators(); modes != "" && slices.Contains(m.cfg.HUDFields, HUDFieldModes) {
+		status += " " + modes
+	}
+
+	hudText := fmt.Sprintf("%s | %s\n%s\n%s | Size: s | Color: c/C | Ramp: r (%s) | Zen: z", wpmStr, timeRemaining, progressBar, status, rampStatus)
 
 	// Add navigation hint for Miniflux users
 	if m.minifluxClient != nil {
 		hudText += " | Esc: Back | o: Open | f: Star"
 	}
+	if m.finding {
+		hudText = fmt.Sprintf("%s\nFind: %s (Enter: Jump, Esc: Cancel)", hudText, m.findInput.View())
+	} else if m.findTerm != "" {
+		hudText = fmt.Sprintf("%s\nFind %q: %d/%d (n/N: Next/Previous, /: New Search)", hudText, m.findTerm, m.matchPosition(), len(m.findMatches))
+	}
 	if m.currentEntry != nil {
-		hudText = fmt.Sprintf("%s\nTitle: %s", hudText, m.currentEntry.Title)
+		if line := m.entryHUDLine(); line != "" {
+			hudText += "\n" + line
+		}
+	} else if m.currentFile != "" {
+		hudText = fmt.Sprintf("%s\nFile: %s | Esc: Back", hudText, m.currentFile)
+		if m.playlistDir != "" {
+			autoAdvance := "off"
+			if m.autoAdvance {
+				autoAdvance = "on"
+			}
+			hudText += fmt.Sprintf(" | Auto-advance: A (%s)", autoAdvance)
+		}
 	}
 
 	var hudRendered string
@@ -1466,12 +3709,23 @@ func (m model) viewReading() string {
 	mainHeight := totalHeight - hudHeight
 	mainHeight = max(mainHeight, 0)
 
-	showSeparators := m.height > 10
+	showSeparators := m.height > 10 && m.cfg.SeparatorStyle != SeparatorNone
 	verticalGap := 1
 
-	contentBlockHeight := 1
+	contentBlockHeight := len(contentLines)
 	if showSeparators && !m.zenMode {
-		contentBlockHeight = 1 + (1+verticalGap)*2
+		contentBlockHeight += (1 + verticalGap) * 2
+	}
+
+	// Quick actions while paused, under the word
+	var menuRendered string
+	if m.breakDue {
+		reminder := fmt.Sprintf("Time for a break: you've been reading for %d minutes\nSpace: Continue | t: Take a break (stay paused)", m.cfg.BreakReminderMinutes)
+		menuRendered = focusStyle.Width(m.width).Align(lipgloss.Center).Render(reminder)
+		contentBlockHeight += 1 + lipgloss.Height(menuRendered)
+	} else if m.paused && m.showPauseMenu {
+		menuRendered = hudStyle.Width(m.width).Align(lipgloss.Center).Render(m.pauseMenuText())
+		contentBlockHeight += 1 + lipgloss.Height(menuRendered)
 	}
 
 	topPadding := (mainHeight - contentBlockHeight) / 2
@@ -1496,7 +3750,9 @@ func (m model) viewReading() string {
 		}
 	}
 
-	sb.WriteString(contentLine + "\n")
+	for _, line := range contentLines {
+		sb.WriteString(line + "\n")
+	}
 
 	if showSeparators && !m.zenMode {
 		for range verticalGap {
@@ -1505,6 +3761,10 @@ func (m model) viewReading() string {
 		sb.WriteString(separator + "\n")
 	}
 
+	if menuRendered != "" {
+		sb.WriteString(blankLine + "\n" + menuRendered + "\n")
+	}
+
 	// Bottom Fill
 	for i := 0; i < bottomPadding; i++ {
 		sb.WriteString(blankLine + "\n")
@@ -1518,6 +3778,173 @@ func (m model) viewReading() string {
 	return sb.String()
 }
 
+// renderWordLine renders a full-width line with the word's ORP letter at the horizontal center
+func (m model) renderWordLine(word string) string {
+	left, focus, right := calculateORP(word, m.cfg.ORPMode)
+
+	if m.textSize != TextNormal && !m.cfg.ASCIIMode {
+		left = toFullWidth(left)
+		focus = toFullWidth(focus)
+		right = toFullWidth(right)
+	}
+
+	// ORP Alignment Logic
+	centerX := m.width / 2
+
+	textStyle := normalStyle
+	if m.cfg.ProgressGradient && !m.cfg.ReducedMotion && len(m.content) > 1 {
+		// The letters around the focus drift from one color to the other over the article
+		progress := float64(m.index) / float64(len(m.content)-1)
+		textStyle = textStyle.Foreground(lipgloss.Color(blendHex(m.cfg.GradientStart, m.cfg.GradientEnd, progress)))
+	}
+	if m.emphasized(m.index) {
+		textStyle = textStyle.Italic(true)
+	}
+	if m.paused && m.matchPosition() > 0 {
+		textStyle = textStyle.Underline(true) // Highlight a find match
+	}
+	leftStr := textStyle.Render(left)
+	focusStr := focusStyle.Render(focus)
+	rightStr := textStyle.Render(right)
+	if m.bionic {
+		// The bold start runs through the ORP letter, so its length follows the ORP mode
+		leftStr = textStyle.Bold(true).Render(left)
+		focusStr = textStyle.Bold(true).Render(focus)
+	}
+
+	// Left Padding
+	leftLen := lipgloss.Width(left) // Width of the characters
+	padLen := centerX - leftLen
+	if focus == "" {
+		// A symbol has no focus letter to line up, so the whole of it is centered, like the words around it
+		padLen = (m.width - lipgloss.Width(left+right)) / 2
+	}
+	padLen = max(padLen, 0)
+	leftPadding := normalStyle.Render(strings.Repeat(" ", padLen))
+
+	// Right Padding
+	currentContentWidth := lipgloss.Width(leftPadding) + lipgloss.Width(leftStr) + lipgloss.Width(focusStr) + lipgloss.Width(rightStr)
+	rightPadLen := m.width - currentContentWidth
+	rightPadLen = max(rightPadLen, 0)
+	rightPadding := normalStyle.Render(strings.Repeat(" ", rightPadLen))
+
+	return leftPadding + leftStr + focusStr + rightStr + rightPadding
+}
+
+// blendHex mixes two "#rrggbb" colors, t of the way from a to b
+func blendHex(a, b string, t float64) string {
+	ar, ag, ab, _ := parseHexColor(a)
+	br, bg, bb, _ := parseHexColor(b)
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", mix(ar, br), mix(ag, bg), mix(ab, bb))
+}
+
+// parseHexColor reads a "#rrggbb" color
+func parseHexColor(s string) (r, g, b uint8, ok bool) {
+	if len(s) != 7 || s[0] != '#' {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), true
+}
+
+// renderBlockWord draws the word in block letters several lines tall, with the ORP letter's glyph
+// at the horizontal center. It reports false when a letter has no glyph or the word doesn't fit,
+// so the caller can fall back to wide text.
+func (m model) renderBlockWord(word string) ([]string, bool) {
+	left, focus, right := calculateORP(word, m.cfg.ORPMode)
+	glyphsFor := func(s string) ([][]string, bool) {
+		var glyphs [][]string
+		for _, r := range strings.ToUpper(s) {
+			glyph, ok := blockFont[r]
+			if !ok {
+				return nil, false
+			}
+			glyphs = append(glyphs, strings.Split(glyph, "|"))
+		}
+		return glyphs, true
+	}
+	leftGlyphs, okLeft := glyphsFor(left)
+	focusGlyphs, okFocus := glyphsFor(focus)
+	rightGlyphs, okRight := glyphsFor(right)
+	if !okLeft || !okFocus || !okRight || len(focusGlyphs) == 0 {
+		return nil, false
+	}
+
+	// Glyphs are separated by one column
+	row := func(glyphs [][]string, i int) string {
+		var parts []string
+		for _, g := range glyphs {
+			parts = append(parts, strings.NewReplacer("#", glyph.Block, ".", " ").Replace(g[i]))
+		}
+		return strings.Join(parts, " ")
+	}
+	gap := func(glyphs [][]string) string {
+		if len(glyphs) == 0 {
+			return ""
+		}
+		return " "
+	}
+
+	leftWidth := lipgloss.Width(row(leftGlyphs, 0) + gap(leftGlyphs))
+	focusWidth := lipgloss.Width(focusGlyphs[0][0])
+	totalWidth := leftWidth + focusWidth + lipgloss.Width(gap(rightGlyphs)+row(rightGlyphs, 0))
+	if totalWidth > m.width {
+		return nil, false
+	}
+	padLen := max(m.width/2-leftWidth-focusWidth/2, 0)
+	rightPadLen := max(m.width-padLen-totalWidth, 0)
+
+	glyphFocusStyle := focusStyle
+	if m.bionic {
+		glyphFocusStyle = normalStyle // Block letters have no bold, so bionic just drops the color
+	}
+	lines := make([]string, blockFontHeight)
+	for i := range lines {
+		lines[i] = normalStyle.Render(strings.Repeat(" ", padLen)) +
+			normalStyle.Render(row(leftGlyphs, i)+gap(leftGlyphs)) +
+			glyphFocusStyle.Render(row(focusGlyphs, i)) +
+			normalStyle.Render(gap(rightGlyphs)+row(rightGlyphs, i)) +
+			normalStyle.Render(strings.Repeat(" ", rightPadLen))
+	}
+	return lines, true
+}
+
+// renderContextLine renders a neighbouring word for the stacked layout, dimmed and centered.
+// offset is the word's position relative to the current one; words already shown are faded
+// further or hidden as PastWords asks, leaving a trail behind the reading position.
+func (m model) renderContextLine(word string, offset int) string {
+	if offset < 0 {
+		switch m.cfg.PastWords {
+		case PastWordsHide:
+			word = ""
+		case PastWordsDim:
+			return hudStyle.Faint(true).Width(m.width).Render(m.contextWord(word))
+		}
+	}
+	return hudStyle.Width(m.width).Render(m.contextWord(word))
+}
+
+// contextWord widens a neighbouring word to match the current one's text size
+func (m model) contextWord(word string) string {
+	if m.textSize != TextNormal && !m.cfg.ASCIIMode {
+		return toFullWidth(word)
+	}
+	return word
+}
+
+// Past Words, choosing how the stacked layout shows the word already read
+const (
+	PastWordsShow = "show" // As dim as the next word
+	PastWordsDim  = "dim"  // Fainter than the next word
+	PastWordsHide = "hide" // A blank line in its place
+)
+
 // Commands
 func fetchEntries(client *miniflux.Client, search string, categoryID int64, feedID int64, offset int, youtubeOnly bool) tea.Cmd {
 	return func() tea.Msg {
@@ -1566,6 +3993,7 @@ func fetchEntries(client *miniflux.Client, search string, categoryID int64, feed
 			result:     &miniflux.EntryResultSet{Entries: collected, Total: total},
 			offset:     offset,
 			nextOffset: nextOffset,
+			search:     search,
 		}
 	}
 }
@@ -1585,16 +4013,94 @@ type FeedCache struct {
 	Feeds    miniflux.Feeds `json:"feeds"`
 }
 
-func getFeedCachePath() string {
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		return "speedreader_feeds.json"
+// positionsPath is the file holding reading positions in local files, by path
+func positionsPath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), "speedreader_positions.json")
+}
+
+// savedPosition is how far through a file reading got. Words is the file's length then,
+// so a position isn't restored into a file that has changed since.
+type savedPosition struct {
+	Index int       `json:"index"`
+	Words int       `json:"words"`
+	Saved time.Time `json:"saved"`
+}
+
+// positionSaveInterval saves the position after this long even when fewer than
+// PositionSaveWords words have passed, e.g. at slow speeds
+const positionSaveInterval = 10 * time.Second
+
+// positionsMu keeps saves, which run as commands, from overwriting each other
+var positionsMu sync.Mutex
+
+func loadPositions() map[string]savedPosition {
+	positions := make(map[string]savedPosition)
+	if data, err := os.ReadFile(positionsPath()); err == nil {
+		json.Unmarshal(data, &positions)
 	}
-	return filepath.Join(configDir, "speedreader_feeds.json")
+	return positions
 }
 
-func loadFeedCache() (miniflux.Feeds, bool) {
-	data, err := os.ReadFile(getFeedCachePath())
+// storePosition records the word index reached in a file; a negative index forgets the file,
+// as when it has been read to the end
+func storePosition(path string, index, words int) error {
+	positionsMu.Lock()
+	defer positionsMu.Unlock()
+	positions := loadPositions()
+	if index < 0 {
+		if _, ok := positions[path]; !ok {
+			return nil
+		}
+		delete(positions, path)
+	} else {
+		positions[path] = savedPosition{Index: index, Words: words, Saved: time.Now()}
+	}
+	data, err := json.Marshal(positions)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(positionsPath(), data, 0644)
+}
+
+// savePosition stores the position in the background
+func savePosition(path string, index, words int) tea.Cmd {
+	return func() tea.Msg {
+		return positionSavedMsg{err: storePosition(path, index, words)}
+	}
+}
+
+// restorePosition moves to where the file being read was left, if it hasn't changed since
+func (m model) restorePosition() model {
+	m.positionSaved = 0
+	m.positionSavedTime = time.Now()
+	saved, ok := loadPositions()[m.positionFile]
+	if !ok || saved.Words != len(m.content) || saved.Index <= 0 || saved.Index >= len(m.content) {
+		return m
+	}
+	m.index = saved.Index
+	m.positionSaved = saved.Index
+	m.notice = fmt.Sprintf("resumed at word %s, g to start over", formatThousands(saved.Index+1))
+	return m
+}
+
+// positionDue reports whether the position has moved far enough, or long enough ago, to save.
+// leaving saves any change at all, for when the reader is closed.
+func (m model) positionDue(leaving bool) bool {
+	if m.positionFile == "" || m.finished || m.index == m.positionSaved || len(m.content) == 0 {
+		return false
+	}
+	moved := m.index - m.positionSaved
+	return leaving || moved >= m.cfg.PositionSaveWords || moved <= -m.cfg.PositionSaveWords ||
+		time.Since(m.positionSavedTime) >= positionSaveInterval
+}
+
+func getFeedCachePath() string {
+	// Keep the cache next to the config so an overridden config path is self-contained
+	return filepath.Join(filepath.Dir(getConfigPath()), "speedreader_feeds.json")
+}
+
+func loadFeedCache() (miniflux.Feeds, bool) {
+	data, err := os.ReadFile(getFeedCachePath())
 	if err != nil {
 		return nil, false
 	}
@@ -1658,311 +4164,2767 @@ func refreshErroredFeedsOnStart(client *miniflux.Client) tea.Cmd {
 	}
 }
 
-func fetchContent(htmlContent string) tea.Cmd {
+// lineBreakHyphen matches a word split across lines, like "inter-\nnational", but not
+// a compound that merely ends a line before a capitalised word or a paragraph break
+var lineBreakHyphen = regexp.MustCompile(`(\p{L})-[ \t]*\r?\n[ \t]*(\p{Ll})`)
+
+// prepareText applies the text-level preprocessing that has to see line breaks
+func prepareText(text string, cfg Config) string {
+	if !cfg.KeepANSI {
+		text = stripANSI(text)
+	}
+	if !cfg.KeepInvisible {
+		text = invisibleChars.Replace(text)
+	}
+	if cfg.JoinHyphenation {
+		text = lineBreakHyphen.ReplaceAllString(text, "$1$2")
+	}
+	text = cjkBreak.ReplaceAllString(text, "$1 $2")
+	if cfg.URLHandling != URLKeep {
+		text = bareURL.ReplaceAllStringFunc(text, func(match string) string {
+			return rewriteURL(match, cfg.URLHandling)
+		})
+	}
+	return text
+}
+
+// ansiEscape matches terminal escape sequences: CSI (colors, cursor moves), OSC (titles,
+// hyperlinks) ended by BEL or ST, and the short escapes such as charset switches
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[ -/]*[0-~]`)
+
+// stripANSI removes terminal escape sequences, so text piped from tools like bat reads cleanly
+func stripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
+
+// invisibleChars removes characters that take no space, which would otherwise leave blank words
+// or glue words together, and turns no-break and other odd spaces into plain ones so the
+// ASCII-only \s in the patterns here matches them. Joiners are kept, as emoji and some scripts need them.
+var invisibleChars = strings.NewReplacer(
+	"\u200b", "", // Zero-width space
+	"\u2060", "", // Word joiner
+	"\ufeff", "", // Byte order mark, or zero-width no-break space
+	"\u00ad", "", // Soft hyphen
+	"\u00a0", " ", // No-break space
+	"\u2007", " ", // Figure space
+	"\u202f", " ", // Narrow no-break space
+)
+
+// cjkBreak finds a run of full-width punctuation and closing quotes run straight into the next
+// character, so a space can be put after the whole run rather than inside it
+var cjkBreak = regexp.MustCompile(`([。！？，、；][。！？，、；」』》）]*)([^\s。！？，、；」』》）])`)
+
+// bareURL matches a URL written out in the text, with any spaces and opening bracket before it
+var bareURL = regexp.MustCompile(`[ \t]*[(\[]?\b(?:https?://|www\.)[^\s<>"]+`)
+
+// rewriteURL shortens a bareURL match to its host or removes it, keeping punctuation that ends
+// the sentence so the reading rhythm is unchanged
+func rewriteURL(match string, mode string) string {
+	link := strings.TrimLeft(match, " \t")
+	space := match[:len(match)-len(link)]
+	bracketed := strings.TrimLeft(link, "([")
+	open := link[:len(link)-len(bracketed)]
+	trimmed := strings.TrimRight(bracketed, ".,;:!?)]}'")
+	trailing := bracketed[len(trimmed):]
+
+	if mode == URLStrip {
+		// Drop the brackets too rather than leave them empty
+		if open == "(" {
+			trailing = strings.Replace(trailing, ")", "", 1)
+		} else if open == "[" {
+			trailing = strings.Replace(trailing, "]", "", 1)
+		}
+		return trailing
+	}
+	target := trimmed
+	if !strings.Contains(target, "://") {
+		target = "http://" + target
+	}
+	parsed, err := url.Parse(target)
+	if err != nil || parsed.Hostname() == "" {
+		return match
+	}
+	return space + open + strings.TrimPrefix(parsed.Hostname(), "www.") + trailing
+}
+
+// prepareWords turns text into the word stream shown by the reader.
+// Words are split on spaces, which Chinese and Japanese don't use, so that text is only broken
+// after its punctuation (see cjkBreak) and each "word" is a whole clause.
+func prepareWords(text string, cfg Config) []string {
+	text = prepareText(text, cfg)
+	if cfg.KeepInvisible {
+		return strings.Fields(text)
+	}
+	return normalizeWords(text)
+}
+
+// normalizeWords splits text into words on any run of whitespace, tabs and odd spaces included,
+// without the invisible characters that would leave blank words or glue two together
+func normalizeWords(text string) []string {
+	return strings.Fields(invisibleChars.Replace(text))
+}
+
+func fetchContent(htmlContent string, cfg Config) tea.Cmd {
 	return func() tea.Msg {
-		text := html2text.HTML2Text(htmlContent)
-		if text == "" {
-			text = "Content could not be extracted from this article."
+		return convertContent(htmlContent, cfg)
+	}
+}
+
+// convertContent turns an entry's HTML into the text and links the reader shows.
+// Bold and italic spans are kept in the text as emphasis marks, for splitEmphasis to pick up.
+func convertContent(htmlContent string, cfg Config) contentMsg {
+	text := attachEmphasisMarks(html2text.HTML2Text(markEmphasis(htmlContent)))
+	if strings.Trim(text, emphasisStart+emphasisEnd) == "" {
+		text = "Content could not be extracted from this article."
+	}
+	// Find links in the prepared text so their word positions match what is shown
+	text = prepareText(text, cfg)
+	links := extractLinks(htmlContent, emphasisMarks.Replace(text))
+	return splitContent(text, links, cfg)
+}
+
+// splitContent splits text into the words the reader shows. It runs in the command that loaded
+// the text, as splitting a book takes long enough to stall the UI.
+func splitContent(text string, links []ArticleLink, cfg Config) contentMsg {
+	words, emphasis := splitEmphasis(prepareWords(text, cfg))
+	structure := structureOf(emphasisMarks.Replace(text)) // A mark after "world." would hide the full stop
+	return contentMsg{text: text, words: words, emphasis: emphasis, structure: structure, links: links}
+}
+
+// Emphasis marks, private use characters put around bold and italic text before conversion
+// so the emphasis survives html2text
+const (
+	emphasisStart = "\ue000"
+	emphasisEnd   = "\ue001"
+)
+
+// emphasisMarks removes emphasis marks from text
+var emphasisMarks = strings.NewReplacer(emphasisStart, "", emphasisEnd, "")
+
+var (
+	emphasisOpenTag  = regexp.MustCompile(`(?i)<(?:b|strong|em|i)(?:\s[^>]*)?>`)
+	emphasisCloseTag = regexp.MustCompile(`(?i)</(?:b|strong|em|i)\s*>`)
+	emptyEmphasis    = regexp.MustCompile(`\x{E000}(\s*)\x{E001}`)
+	looseEmphasis    = regexp.MustCompile(`(\x{E000}+)(\s+)`)
+	looseEmphasisEnd = regexp.MustCompile(`(\s+)(\x{E001}+)`)
+)
+
+// markEmphasis puts emphasis marks inside bold and italic tags
+func markEmphasis(htmlContent string) string {
+	htmlContent = emphasisOpenTag.ReplaceAllString(htmlContent, "${0}"+emphasisStart)
+	return emphasisCloseTag.ReplaceAllString(htmlContent, emphasisEnd+"${0}")
+}
+
+// attachEmphasisMarks moves emphasis marks onto the words they belong to, so none is left as a
+// word of its own, e.g. "<b> bold </b>" marks "bold" rather than the spaces around it
+func attachEmphasisMarks(text string) string {
+	text = emptyEmphasis.ReplaceAllString(text, "$1")
+	text = looseEmphasis.ReplaceAllString(text, "$2$1")
+	return looseEmphasisEnd.ReplaceAllString(text, "$2$1")
+}
+
+// splitEmphasis removes emphasis marks from words, reporting which words were inside them.
+// The flags are nil when nothing was emphasized.
+func splitEmphasis(words []string) ([]string, []bool) {
+	var flags []bool
+	depth := 0
+	for i, word := range words {
+		if !strings.ContainsAny(word, emphasisStart+emphasisEnd) {
+			if depth > 0 {
+				flags[i] = true
+			}
+			continue
 		}
-		links := extractLinks(htmlContent, text)
-		return contentMsg{text: text, links: links}
+		if flags == nil {
+			flags = make([]bool, len(words))
+		}
+		flags[i] = depth > 0
+		for _, r := range word {
+			switch string(r) {
+			case emphasisStart:
+				depth++
+				flags[i] = true
+			case emphasisEnd:
+				depth = max(depth-1, 0)
+			}
+		}
+		words[i] = emphasisMarks.Replace(word)
 	}
+	return words, flags
 }
 
-func filterYouTubeEntries(entries []*miniflux.Entry) []*miniflux.Entry {
-	filtered := make([]*miniflux.Entry, 0, len(entries))
-	for _, entry := range entries {
-		if isYouTubeEntry(entry) {
-			filtered = append(filtered, entry)
+// prefetchDelay is how long the cursor must rest on an entry before it is prefetched
+const prefetchDelay = 250 * time.Millisecond
+
+// contentCacheLimit bounds how many converted articles are kept in memory
+const contentCacheLimit = 20
+
+// contentCache holds converted article content by entry ID, evicting the least recently used.
+// The model holds a pointer so that copies of the model share one cache.
+type contentCache struct {
+	limit int
+	order []int64 // Least recently used first
+	items map[int64]contentMsg
+}
+
+func newContentCache(limit int) *contentCache {
+	return &contentCache{limit: limit, items: make(map[int64]contentMsg)}
+}
+
+func (c *contentCache) has(id int64) bool {
+	_, ok := c.items[id]
+	return ok
+}
+
+// get returns the cached content for id, marking it as recently used
+func (c *contentCache) get(id int64) (contentMsg, bool) {
+	content, ok := c.items[id]
+	if ok {
+		c.touch(id)
+	}
+	return content, ok
+}
+
+func (c *contentCache) put(id int64, content contentMsg) {
+	if _, ok := c.items[id]; !ok && len(c.order) >= c.limit {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		delete(c.items, oldest)
+	}
+	c.items[id] = content
+	c.touch(id)
+}
+
+// touch moves id to the most recently used end of the order
+func (c *contentCache) touch(id int64) {
+	for i, cached := range c.order {
+		if cached == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
 		}
 	}
-	return filtered
+	c.order = append(c.order, id)
 }
 
-func isYouTubeEntry(entry *miniflux.Entry) bool {
-	if entry == nil {
-		return false
+// appendNewEntries appends the entries of next whose IDs aren't already in entries
+func appendNewEntries(entries, next []*miniflux.Entry) []*miniflux.Entry {
+	seen := make(map[int64]bool, len(entries))
+	for _, entry := range entries {
+		seen[entry.ID] = true
 	}
-	if isYouTubeURL(entry.URL) {
-		return true
+	for _, entry := range next {
+		if !seen[entry.ID] {
+			seen[entry.ID] = true
+			entries = append(entries, entry)
+		}
 	}
-	for _, link := range extractLinks(entry.Content, "") {
-		if isYouTubeURL(link.URL) {
-			return true
+	return entries
+}
+
+// digestByFeed groups entries by feed, busiest feeds first
+func digestByFeed(entries []*miniflux.Entry) []feedDigest {
+	byFeed := make(map[int64]*feedDigest)
+	var order []int64
+	for _, entry := range entries {
+		d, ok := byFeed[entry.FeedID]
+		if !ok {
+			title := fmt.Sprintf("Feed #%d", entry.FeedID)
+			if entry.Feed != nil && entry.Feed.Title != "" {
+				title = entry.Feed.Title
+			}
+			d = &feedDigest{FeedID: entry.FeedID, Title: title, Oldest: entry.Date}
+			byFeed[entry.FeedID] = d
+			order = append(order, entry.FeedID)
+		}
+		d.Count++
+		if entry.Date.Before(d.Oldest) {
+			d.Oldest = entry.Date
 		}
 	}
-	return false
+
+	digest := make([]feedDigest, 0, len(order))
+	for _, id := range order {
+		digest = append(digest, *byFeed[id])
+	}
+	sort.SliceStable(digest, func(i, j int) bool {
+		if digest[i].Count != digest[j].Count {
+			return digest[i].Count > digest[j].Count
+		}
+		return strings.ToLower(digest[i].Title) < strings.ToLower(digest[j].Title)
+	})
+	return digest
 }
 
-func isYouTubeURL(rawURL string) bool {
-	rawURL = strings.TrimSpace(rawURL)
-	if rawURL == "" {
-		return false
+// HUD Fields, shown on the article line of the reading HUD in the order given; modes sits on
+// the status line instead, for files as well as articles
+const (
+	HUDFieldTitle = "title"
+	HUDFieldFeed  = "feed"
+	HUDFieldModes = "modes"
+)
+
+func defaultHUDFields() []string {
+	return []string{HUDFieldTitle, HUDFieldFeed, HUDFieldModes}
+}
+
+// File Finish Actions, for text that isn't a Miniflux entry
+const (
+	FileFinishPause = "pause" // Stay on the last word
+	FileFinishLoop  = "loop"  // Start again from the top
+	FileFinishList  = "list"  // Go back to the Miniflux list or playlist, if there is one
+	FileFinishQuit  = "quit"
+)
+
+// URL Handling, for links written out in an article's text
+const (
+	URLKeep   = "keep"
+	URLStrip  = "strip"  // Remove them from the word stream
+	URLDomain = "domain" // Show just the host
+)
+
+// Separator Styles
+const (
+	SeparatorSolid  = "solid"
+	SeparatorDashed = "dashed"
+	SeparatorNone   = "none"
+)
+
+const defaultSeparatorChar = "─"
+
+// Metronome Modes
+const (
+	MetronomeOff   = "off"
+	MetronomePulse = "pulse" // Briefly brighten the separator lines
+	MetronomeBell  = "bell"  // Ring the terminal bell
+)
+
+const (
+	metronomeMinInterval = 300 * time.Millisecond // Beats never come faster than this, whatever the WPM
+	metronomePulseLength = 80 * time.Millisecond
+)
+
+// Playlist Sort Orders
+const (
+	PlaylistSortName  = "name"
+	PlaylistSortMTime = "mtime"
+)
+
+// playlistExtensions are the file types a directory playlist will offer
+var playlistExtensions = map[string]bool{".txt": true, ".md": true, ".html": true, ".htm": true, ".docx": true}
+
+// loadPlaylist lists the readable files in dir, skipping subdirectories and unsupported types
+func loadPlaylist(dir string, sortBy string) ([]playlistItem, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
 	}
 
-	parsed, err := url.Parse(rawURL)
-	if err != nil || parsed.Host == "" {
-		parsed, err = url.Parse("https://" + rawURL)
+	var items []playlistItem
+	for _, de := range dirEntries {
+		if de.IsDir() || !playlistExtensions[strings.ToLower(filepath.Ext(de.Name()))] {
+			continue
+		}
+		info, err := de.Info()
 		if err != nil {
-			return false
+			continue
 		}
+		items = append(items, playlistItem{Name: de.Name(), Path: filepath.Join(dir, de.Name()), ModTime: info.ModTime()})
 	}
+	sortPlaylist(items, sortBy)
+	return items, nil
+}
 
-	host := strings.ToLower(parsed.Hostname())
-	host = strings.TrimPrefix(host, "www.")
-	host = strings.TrimPrefix(host, "m.")
-	path := strings.TrimRight(parsed.EscapedPath(), "/")
+// sortPlaylist orders files by name, or newest first when sorting by modification time
+func sortPlaylist(items []playlistItem, sortBy string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if sortBy == PlaylistSortMTime {
+			return items[i].ModTime.After(items[j].ModTime)
+		}
+		return strings.ToLower(items[i].Name) < strings.ToLower(items[j].Name)
+	})
+}
 
-	if host == "youtu.be" {
-		return path != ""
+// readFileText reads a file as plain text, converting HTML so markup doesn't end up in the word stream
+func readFileText(path string, cfg Config) (string, []ArticleLink, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
 	}
-	if !isYouTubeHost(host) {
-		return false
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		text := prepareText(html2text.HTML2Text(string(data)), cfg)
+		return text, extractLinks(string(data), text), nil
+	case ".docx":
+		text, err := docxText(data)
+		return text, nil, err
 	}
+	return string(data), nil, nil
+}
 
-	switch {
-	case path == "/watch" && parsed.Query().Get("v") != "":
-		return true
-	case strings.HasPrefix(path, "/shorts/"):
-		return true
-	case strings.HasPrefix(path, "/embed/"):
-		return true
-	case strings.HasPrefix(path, "/live/"):
-		return true
+// docxText extracts the text of a Word document: paragraphs become lines and table cells are
+// separated by tabs. Headers, footers and footnotes are left out.
+func docxText(data []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("not a Word document: %w", err)
+	}
+	doc, err := archive.Open("word/document.xml")
+	if err != nil {
+		return "", fmt.Errorf("not a Word document: %w", err)
+	}
+	defer doc.Close()
+	// A few MB on disk can unpack to far more. The unpacked size in the zip header is safe
+	// to go by, as reading fails once the data runs past it.
+	info, err := doc.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.Size() > maxInputFileSize {
+		return "", fmt.Errorf("document is %s MB unpacked, more than the %d MB the reader will load",
+			formatThousands(int(info.Size()>>20)), maxInputFileSize>>20)
 	}
 
-	return false
+	var sb strings.Builder
+	inText := false
+	cellDepth := 0 // Paragraphs inside a table cell stay on the row's line
+	decoder := xml.NewDecoder(doc)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("reading document.xml: %w", err)
+		}
+
+		// Elements are matched by local name, as the "w" namespace prefix is only a convention
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tc":
+				cellDepth++
+			case "tab":
+				sb.WriteString("\t")
+			case "br", "cr":
+				sb.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				if cellDepth > 0 {
+					sb.WriteString(" ")
+				} else {
+					sb.WriteString("\n")
+				}
+			case "tc":
+				cellDepth--
+				sb.WriteString("\t")
+			case "tr":
+				sb.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText {
+				sb.Write(t)
+			}
+		}
+	}
+
+	text := strings.TrimSpace(sb.String())
+	if text == "" {
+		return "", errors.New("the Word document has no text")
+	}
+	return text, nil
 }
 
-func isYouTubeHost(host string) bool {
-	return host == "youtube.com" ||
-		strings.HasSuffix(host, ".youtube.com") ||
-		host == "youtube-nocookie.com" ||
-		strings.HasSuffix(host, ".youtube-nocookie.com")
+// errClipboardEmpty is returned when the clipboard holds nothing worth reading
+var errClipboardEmpty = errors.New("clipboard is empty, copy some text first")
+
+// readClipboard returns the clipboard text, failing if there is none
+func readClipboard() (string, error) {
+	text, err := clipboard.ReadAll()
+	if err != nil {
+		return "", fmt.Errorf("could not read clipboard: %w", err)
+	}
+	if strings.TrimSpace(text) == "" {
+		return "", errClipboardEmpty
+	}
+	return text, nil
 }
 
-// extractLinks extracts all links from HTML content and tracks their word positions in the converted text
-func extractLinks(htmlContent string, convertedText string) []ArticleLink {
-	var links []ArticleLink
-	seen := make(map[string]bool)
+// editorCommand builds the command for $VISUAL or $EDITOR, falling back to vi (notepad on Windows)
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
 
-	// Convert text to words for position tracking
-	words := strings.Fields(convertedText)
+	// Allow editors that need arguments, like "code --wait"
+	args := strings.Fields(editor)
+	return exec.Command(args[0], append(args[1:], path)...)
+}
 
-	// Match <a href="...">text</a> patterns
-	linkRegex := regexp.MustCompile(`<a[^>]*href=["']([^"']+)["'][^>]*>([^<]*)</a>`)
-	matches := linkRegex.FindAllStringSubmatch(htmlContent, -1)
+// glossLookahead is how many words from the current one have their gloss looked up ahead of time
+const glossLookahead = 3
 
-	// Track position in words as we find links
-	searchStartWord := 0
+// glossTimeout stops a slow dictionary command from holding a lookup open
+const glossTimeout = 5 * time.Second
 
-	for _, match := range matches {
-		if len(match) >= 3 {
-			url := match[1]
-			text := strings.TrimSpace(match[2])
+// glossCacheLimit bounds how many glosses are kept; the cache starts over once it is full
+const glossCacheLimit = 1000
 
-			// Skip empty URLs, anchors, and javascript links
-			if url == "" || strings.HasPrefix(url, "#") || strings.HasPrefix(url, "javascript:") {
-				continue
-			}
+// glossCache holds word definitions by glossKey, shared between copies of the model like contentCache
+type glossCache struct {
+	texts   map[string]string
+	pending map[string]bool // Looked up but not answered yet
+}
 
-			// Skip duplicates
-			if seen[url] {
-				continue
-			}
-			seen[url] = true
+func newGlossCache() *glossCache {
+	return &glossCache{texts: make(map[string]string), pending: make(map[string]bool)}
+}
 
-			// Use URL as text if text is empty
-			if text == "" {
-				text = url
-			}
+func (c *glossCache) get(key string) (string, bool) {
+	text, ok := c.texts[key]
+	return text, ok
+}
 
-			// Find word position by searching for the first word of the link text
-			wordIndex := -1
-			linkWords := strings.Fields(text)
-			if len(linkWords) > 0 {
-				firstWord := strings.ToLower(linkWords[0])
-				// Search from where we left off to maintain order
-				for i := searchStartWord; i < len(words); i++ {
-					// Check if this word matches (case-insensitive, strip punctuation)
-					w := strings.ToLower(strings.Trim(words[i], ".,!?;:\"'()[]{}"))
-					if w == firstWord || strings.HasPrefix(w, firstWord) || strings.HasPrefix(firstWord, w) {
-						wordIndex = i
-						searchStartWord = i + 1
-						break
-					}
-				}
-			}
+func (c *glossCache) put(key, text string) {
+	if len(c.texts) >= glossCacheLimit {
+		c.texts = make(map[string]string)
+	}
+	c.texts[key] = text
+	delete(c.pending, key)
+}
 
-			// If we couldn't find it, estimate based on order
-			if wordIndex == -1 {
-				wordIndex = searchStartWord
-			}
+// glossKey is the form of a word that glosses are looked up and cached by
+func glossKey(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+}
+
+// glossActive reports whether inline glosses are on: a command is set and the speed is slow enough to read them
+func (m model) glossActive() bool {
+	return m.cfg.GlossCommand != "" && m.wpm <= m.cfg.GlossMaxWPM && m.glosses != nil
+}
+
+// glossLookups starts lookups for the current and next few words that aren't cached or pending
+func (m model) glossLookups() tea.Cmd {
+	var cmds []tea.Cmd
+	for i := m.index; i < len(m.content) && i <= m.index+glossLookahead; i++ {
+		key := glossKey(m.content[i])
+		if key == "" || m.glosses.pending[key] {
+			continue
+		}
+		if _, ok := m.glosses.get(key); ok {
+			continue
+		}
+		m.glosses.pending[key] = true
+		cmds = append(cmds, lookupGloss(m.cfg.GlossCommand, key))
+	}
+	return tea.Batch(cmds...)
+}
+
+// lookupGloss runs the gloss command with the word as its last argument and keeps the first line it prints.
+// A failed lookup is cached as an empty gloss so it isn't retried on every word.
+func lookupGloss(command, key string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), glossTimeout)
+		defer cancel()
+
+		// Allow commands that need arguments, like "trans -b :en"
+		args := strings.Fields(command)
+		out, err := exec.CommandContext(ctx, args[0], append(args[1:], key)...).Output()
+		if err != nil {
+			return glossMsg{key: key}
+		}
+		line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+		return glossMsg{key: key, text: strings.TrimSpace(line)}
+	}
+}
+
+// openEnclosure plays a media attachment with Config.MediaPlayer, suspending the TUI while it
+// runs, or hands it to the browser when no player is set
+func openEnclosure(enc *miniflux.Enclosure, player string) tea.Cmd {
+	if player == "" {
+		return func() tea.Msg {
+			return mediaMsg{err: browser.OpenURL(enc.URL)}
+		}
+	}
+	// Allow players that need arguments, like "mpv --no-video"
+	args := strings.Fields(player)
+	return tea.ExecProcess(exec.Command(args[0], append(args[1:], enc.URL)...), func(err error) tea.Msg {
+		return mediaMsg{err: err}
+	})
+}
+
+// editContent suspends the TUI to edit the words in an editor and reports the edited text
+func editContent(words []string) tea.Cmd {
+	f, err := os.CreateTemp("", "speedreader-*.txt")
+	if err != nil {
+		return func() tea.Msg { return editedMsg{err: err} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(wordsToText(words))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return editedMsg{err: err} }
+	}
+
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return editedMsg{err: err}
+		}
+		data, err := os.ReadFile(path)
+		return editedMsg{text: string(data), err: err}
+	})
+}
+
+// wordsToText lays words out one sentence per line so they're easy to edit
+func wordsToText(words []string) string {
+	var sb strings.Builder
+	for i, word := range words {
+		sb.WriteString(word)
+		switch {
+		case i == len(words)-1:
+			sb.WriteString("\n")
+		case sentenceEnding(word) != "":
+			sb.WriteString("\n")
+		default:
+			sb.WriteString(" ")
+		}
+	}
+	return sb.String()
+}
+
+func pasteClipboard() tea.Msg {
+	text, err := readClipboard()
+	return clipboardMsg{text: text, err: err}
+}
+
+// loadInputFile reads the file named on the command line, so a large one doesn't hold up the TUI starting
+func loadInputFile(path string, cfg Config) tea.Cmd {
+	return func() tea.Msg {
+		text, err := readInputFile(path)
+		if err != nil {
+			return fileLoadErrMsg{path: path, err: err}
+		}
+		if strings.TrimSpace(text) == "" {
+			return fileLoadErrMsg{path: path, err: errors.New("file has no readable text")}
+		}
+		return splitContent(text, nil, cfg)
+	}
+}
+
+func loadFile(path string, cfg Config) tea.Cmd {
+	return func() tea.Msg {
+		text, links, err := readFileText(path, cfg)
+		if err != nil {
+			return fileLoadErrMsg{path: path, err: err}
+		}
+		if strings.TrimSpace(text) == "" {
+			return fileLoadErrMsg{path: path, err: errors.New("file has no readable text")}
+		}
+		return splitContent(text, links, cfg)
+	}
+}
+
+// csvHeader is written when --csv creates a new file
+var csvHeader = []string{"timestamp", "title", "url", "words", "wpm"}
+
+// appendReadsCSV appends a row per finished article to path, starting the file with a header if it is new
+func appendReadsCSV(path string, reads []readRecord) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		w.Write(csvHeader)
+	}
+	for _, r := range reads {
+		w.Write([]string{
+			r.Finished.Format(time.RFC3339),
+			r.Title,
+			r.URL,
+			strconv.Itoa(r.Words),
+			strconv.Itoa(r.WPM),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func filterYouTubeEntries(entries []*miniflux.Entry) []*miniflux.Entry {
+	filtered := make([]*miniflux.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if isYouTubeEntry(entry) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+func isYouTubeEntry(entry *miniflux.Entry) bool {
+	if entry == nil {
+		return false
+	}
+	if isYouTubeURL(entry.URL) {
+		return true
+	}
+	for _, link := range extractLinks(entry.Content, "") {
+		if isYouTubeURL(link.URL) {
+			return true
+		}
+	}
+	return false
+}
+
+func isYouTubeURL(rawURL string) bool {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return false
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		parsed, err = url.Parse("https://" + rawURL)
+		if err != nil {
+			return false
+		}
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	host = strings.TrimPrefix(host, "m.")
+	path := strings.TrimRight(parsed.EscapedPath(), "/")
+
+	if host == "youtu.be" {
+		return path != ""
+	}
+	if !isYouTubeHost(host) {
+		return false
+	}
+
+	switch {
+	case path == "/watch" && parsed.Query().Get("v") != "":
+		return true
+	case strings.HasPrefix(path, "/shorts/"):
+		return true
+	case strings.HasPrefix(path, "/embed/"):
+		return true
+	case strings.HasPrefix(path, "/live/"):
+		return true
+	}
+
+	return false
+}
+
+func isYouTubeHost(host string) bool {
+	return host == "youtube.com" ||
+		strings.HasSuffix(host, ".youtube.com") ||
+		host == "youtube-nocookie.com" ||
+		strings.HasSuffix(host, ".youtube-nocookie.com")
+}
+
+// extractLinks extracts all links from HTML content and tracks their word positions in the converted text
+func extractLinks(htmlContent string, convertedText string) []ArticleLink {
+	var links []ArticleLink
+	seen := make(map[string]bool)
+
+	// Match <a href="...">text</a> patterns
+	linkRegex := regexp.MustCompile(`<a[^>]*href=["']([^"']+)["'][^>]*>([^<]*)</a>`)
+	matches := linkRegex.FindAllStringSubmatch(htmlContent, -1)
+
+	for _, match := range matches {
+		if len(match) >= 3 {
+			url := match[1]
+			text := strings.TrimSpace(match[2])
+
+			// Skip empty URLs, anchors, and javascript links
+			if url == "" || strings.HasPrefix(url, "#") || strings.HasPrefix(url, "javascript:") {
+				continue
+			}
+
+			// Skip duplicates
+			if seen[url] {
+				continue
+			}
+			seen[url] = true
+
+			// Use URL as text if text is empty
+			if text == "" {
+				text = url
+			}
+
+			links = append(links, ArticleLink{Text: text, URL: url})
+		}
+	}
+
+	// Convert text to words for position tracking
+	return locateLinks(links, strings.Fields(convertedText))
+}
+
+// locateLinks sets each link's WordIndex to where its text first appears in words, keeping the links in order
+func locateLinks(links []ArticleLink, words []string) []ArticleLink {
+	// Track position in words as we find links
+	searchStartWord := 0
+
+	located := make([]ArticleLink, len(links))
+	for j, link := range links {
+		// Find word position by searching for the first word of the link text
+		wordIndex := -1
+		linkWords := strings.Fields(link.Text)
+		if len(linkWords) > 0 {
+			firstWord := strings.ToLower(linkWords[0])
+			// Search from where we left off to maintain order
+			for i := searchStartWord; i < len(words); i++ {
+				// Check if this word matches (case-insensitive, strip punctuation)
+				w := strings.ToLower(strings.Trim(words[i], ".,!?;:\"'()[]{}"))
+				if w == firstWord || strings.HasPrefix(w, firstWord) || strings.HasPrefix(firstWord, w) {
+					wordIndex = i
+					searchStartWord = i + 1
+					break
+				}
+			}
+		}
+
+		// If we couldn't find it, estimate based on order
+		if wordIndex == -1 {
+			wordIndex = searchStartWord
+		}
+
+		link.WordIndex = wordIndex
+		located[j] = link
+	}
+	return located
+}
+
+// catchUpPageSize is how many entries are listed per request when gathering old unread ones
+const catchUpPageSize = 250
+
+// catchUpBatchSize is how many entries are marked read per request, keeping each payload small
+const catchUpBatchSize = 100
+
+// fetchOldUnreadIDs collects the IDs of every unread entry published before cutoff, page by page
+func fetchOldUnreadIDs(client *miniflux.Client, cutoff time.Time) tea.Cmd {
+	return func() tea.Msg {
+		var ids []int64
+		for offset := 0; ; offset += catchUpPageSize {
+			filter := &miniflux.Filter{Status: miniflux.EntryStatusUnread, Before: cutoff.Unix(), Limit: catchUpPageSize, Offset: offset, Order: "id"}
+			result, err := client.Entries(filter)
+			if err != nil {
+				return oldUnreadMsg{err: err}
+			}
+			for _, entry := range result.Entries {
+				ids = append(ids, entry.ID)
+			}
+			if len(result.Entries) < catchUpPageSize {
+				break
+			}
+		}
+		return oldUnreadMsg{ids: ids}
+	}
+}
+
+// markEntriesRead marks entries read in batches, reporting how many were done before any failure
+func markEntriesRead(client *miniflux.Client, ids []int64) tea.Cmd {
+	return func() tea.Msg {
+		marked := 0
+		for batch := range slices.Chunk(ids, catchUpBatchSize) {
+			if err := client.UpdateEntries(batch, miniflux.EntryStatusRead); err != nil {
+				return catchUpDoneMsg{marked: marked, err: err}
+			}
+			marked += len(batch)
+		}
+		return catchUpDoneMsg{marked: marked}
+	}
+}
+
+// updateFeed applies changes to a feed, such as a new category or title
+func updateFeed(client *miniflux.Client, feedID int64, changes *miniflux.FeedModificationRequest) tea.Cmd {
+	return func() tea.Msg {
+		feed, err := client.UpdateFeed(feedID, changes)
+		return feedUpdatedMsg{feed: feed, err: err}
+	}
+}
+
+// subscribeFeed adds a feed to a category. A website address is first looked up with the
+// server's discovery, taking the first feed it finds; if none is found the URL is tried as given.
+func subscribeFeed(client *miniflux.Client, feedURL string, categoryID int64) tea.Cmd {
+	return func() tea.Msg {
+		if found, err := client.Discover(feedURL); err == nil && len(found) > 0 {
+			feedURL = found[0].URL
+		}
+		feedID, err := client.CreateFeed(&miniflux.FeedCreationRequest{FeedURL: feedURL, CategoryID: categoryID})
+		return subscribedMsg{feedID: feedID, err: err}
+	}
+}
+
+// entryWebURL is the entry's page in the Miniflux web UI. The feed route is used because it
+// shows the entry whatever its status, unlike /unread/entry.
+func entryWebURL(serverURL string, entry *miniflux.Entry) string {
+	base := strings.TrimSuffix(strings.TrimRight(serverURL, "/"), "/v1")
+	return fmt.Sprintf("%s/feed/%d/entry/%d", base, entry.FeedID, entry.ID)
+}
+
+// fetchEntry loads a single entry, whatever its status, to open it by ID
+func fetchEntry(client *miniflux.Client, entryID int64) tea.Cmd {
+	return func() tea.Msg {
+		entry, err := client.Entry(entryID)
+		return entryFetchedMsg{id: entryID, entry: entry, err: err}
+	}
+}
+
+// markBatchRead marks several entries read in one request
+func markBatchRead(client *miniflux.Client, ids []int64) tea.Cmd {
+	return func() tea.Msg {
+		err := client.UpdateEntries(ids, miniflux.EntryStatusRead)
+		return batchReadMsg{ids: ids, err: err}
+	}
+}
+
+func markAsRead(client *miniflux.Client, entryID int64) tea.Cmd {
+	return func() tea.Msg {
+		err := client.UpdateEntries([]int64{entryID}, miniflux.EntryStatusRead)
+		return markReadMsg{id: entryID, err: err}
+	}
+}
+
+// newMinifluxClient connects to a Miniflux server with the request timeout, User-Agent and
+// extra headers from the config
+func newMinifluxClient(serverURL, token string, cfg Config) *miniflux.Client {
+	httpClient := &http.Client{Timeout: time.Duration(cfg.RequestTimeoutSeconds) * time.Second}
+	if cfg.UserAgent != "" || len(cfg.HTTPHeaders) > 0 {
+		httpClient.Transport = &headerTransport{base: http.DefaultTransport, userAgent: cfg.UserAgent, headers: cfg.HTTPHeaders}
+	}
+	return miniflux.NewClientWithOptions(
+		serverURL,
+		miniflux.WithAPIKey(token),
+		miniflux.WithHTTPClient(httpClient),
+	)
+}
+
+// headerTransport adds headers to every request, for servers behind proxies that expect them
+type headerTransport struct {
+	base      http.RoundTripper
+	userAgent string
+	headers   map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it was given
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	return t.base.RoundTrip(req)
+}
+
+func testConnection(client *miniflux.Client) tea.Cmd {
+	return func() tea.Msg {
+		user, err := client.Me()
+		if err != nil {
+			return connectionTestMsg{err: err}
+		}
+		return connectionTestMsg{username: user.Username}
+	}
+}
+
+func toggleStarred(client *miniflux.Client, entryID int64) tea.Cmd {
+	return func() tea.Msg {
+		err := client.ToggleStarred(entryID)
+		return starredMsg{id: entryID, err: err}
+	}
+}
+
+// Logic Helpers
+
+func (m model) currentDelay() time.Duration {
+	return m.wordDelayAt(m.index)
+}
+
+// wordDelayAt is how long the word at index i is shown, independent of the reading position
+func (m model) wordDelayAt(i int) time.Duration {
+	d := wordDelay(m.content[i], m.wpm, m.rampSpeed, m.cfg)
+	if m.emphasized(i) {
+		d = time.Duration(float64(d) * m.cfg.EmphasisMultiplier)
+	}
+	return d
+}
+
+// emphasized reports whether the word at index i was bold or italic in the article
+func (m model) emphasized(i int) bool {
+	return i < len(m.emphasis) && m.emphasis[i]
+}
+
+// wordDelay is how long word is shown at wpm, lengthened for long words when rampSpeed is on
+// and for punctuation. It depends only on its arguments, so timing can be worked out without a model.
+func wordDelay(word string, wpm int, rampSpeed bool, cfg Config) time.Duration {
+	baseDelay := 60.0 / float64(wpm)
+
+	// Dashes, rules and arrows carry little to read, so they pass quickly and skip the punctuation pause
+	if isSymbolOnly(word) {
+		return time.Duration(baseDelay * symbolDelayFactor * float64(time.Second))
+	}
+
+	// Complexity Ramping
+	if rampSpeed {
+		length := len(word)
+		switch {
+		case strings.Contains(word, "http"):
+			baseDelay *= 2.0
+		case length > 12:
+			baseDelay *= 1.5
+		case length > 8:
+			baseDelay *= 1.2
+		}
+	}
+
+	// Acronyms and shouting take longer to take in
+	if cfg.CapsMultiplier > 0 && isAllCaps(word) {
+		baseDelay *= cfg.CapsMultiplier
+	}
+
+	// Basic Punctuation detection, Western and CJK
+	last, _ := utf8.DecodeLastRuneInString(word)
+	switch {
+	case strings.ContainsRune(sentenceMarks, last):
+		baseDelay *= 2.0
+	case strings.ContainsRune(clauseMarks, last):
+		baseDelay *= 1.5
+	}
+
+	// Convert seconds to duration
+	return time.Duration(baseDelay * float64(time.Second))
+}
+
+// isAllCaps reports whether word, ignoring its punctuation, is written in capitals and
+// has more than one letter, so "NASA." counts but "I" and "A" don't
+func isAllCaps(word string) bool {
+	letters := 0
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if !unicode.IsUpper(r) {
+			return false
+		}
+		letters++
+	}
+	return letters > 1
+}
+
+// symbolDelayFactor shortens the display time of a token with no letters or digits
+const symbolDelayFactor = 0.5
+
+// isSymbolOnly reports whether word is made only of punctuation and symbols, like "—", "***" or "→"
+func isSymbolOnly(word string) bool {
+	for _, r := range word {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return word != ""
+}
+
+// separatorLine draws a full-width separator from Config.SeparatorChar in Config.SeparatorStyle
+func (m model) separatorLine() string {
+	unit := m.cfg.SeparatorChar
+	if unit == defaultSeparatorChar {
+		unit = glyph.Separator
+	}
+	if m.cfg.SeparatorStyle == SeparatorDashed {
+		unit += " "
+	}
+	unitWidth := max(lipgloss.Width(unit), 1)
+
+	line := strings.Repeat(unit, m.width/unitWidth)
+	return line + strings.Repeat(" ", m.width-lipgloss.Width(line))
+}
+
+// wpmStep is how far k/j change the speed
+const wpmStep = 50
+
+// adjustWPM changes the speed by delta within Config.MinWPM and Config.MaxWPM, noting when a limit stops it
+func (m model) adjustWPM(delta int) model {
+	m.wpm += delta
+	switch {
+	case m.wpm > m.cfg.MaxWPM:
+		m.wpm = m.cfg.MaxWPM
+		m.notice = "max speed"
+	case m.wpm < m.cfg.MinWPM:
+		m.wpm = m.cfg.MinWPM
+		m.notice = "min speed"
+	}
+	return m
+}
+
+// capturesText reports whether keys should go to a text input rather than trigger shortcuts
+func (m model) capturesText() bool {
+	return m.state == StateSearching || m.state == StateLogin || (m.state == StateReading && m.finding) ||
+		(m.state == StateBrowsing && (m.enteringID || m.enteringFeedURL)) || (m.state == StateManageFeeds && m.renamingFeed)
+}
+
+// updateIDInput handles keys while an entry ID is being typed in the list
+func (m model) updateIDInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.enteringID = false
+		m.idInput.Blur()
+		return m, nil
+	case "enter":
+		m.enteringID = false
+		m.idInput.Blur()
+		id, err := strconv.ParseInt(m.idInput.Value(), 10, 64)
+		if err != nil || id <= 0 {
+			m.notice = "not an entry ID"
+			return m, nil
+		}
+		m.loading = true
+		return m, fetchEntry(m.minifluxClient, id)
+	}
+
+	// Entry IDs are numbers, so ignore anything else typed
+	if msg.Type == tea.KeyRunes {
+		for _, r := range msg.Runes {
+			if !unicode.IsDigit(r) {
+				return m, nil
+			}
+		}
+	}
+	var cmd tea.Cmd
+	m.idInput, cmd = m.idInput.Update(msg)
+	return m, cmd
+}
+
+// updateFeedURLInput handles keys while a feed URL is being typed in the list. Enter moves on to
+// the category search, where picking a category subscribes.
+func (m model) updateFeedURLInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.enteringFeedURL = false
+		m.feedURLInput.Blur()
+		return m, nil
+	case "enter":
+		m.enteringFeedURL = false
+		m.feedURLInput.Blur()
+		feedURL := strings.TrimSpace(m.feedURLInput.Value())
+		if feedURL == "" {
+			return m, nil
+		}
+		m.subscribeURL = feedURL
+		return m.pickCategory()
+	}
+
+	var cmd tea.Cmd
+	m.feedURLInput, cmd = m.feedURLInput.Update(msg)
+	return m, cmd
+}
+
+// pickCategory opens the category search for choosing a category for subscribeURL or movingFeed
+func (m model) pickCategory() (tea.Model, tea.Cmd) {
+	m.state = StateSearching
+	m.searchMode = SearchCategory
+	m.searchInput.SetValue("")
+	m.searchInput.Focus()
+	m.searchCursor = 0
+	m.filteredList = nil
+	m.filteredIDs = nil
+	if len(m.categories) == 0 {
+		return m, tea.Batch(textinput.Blink, fetchCategories(m.minifluxClient))
+	}
+	for _, c := range m.categories {
+		m.filteredList = append(m.filteredList, c.Title)
+		m.filteredIDs = append(m.filteredIDs, c.ID)
+	}
+	return m, textinput.Blink
+}
+
+// pickingCategory reports whether the category search is choosing a category rather than filtering
+func (m model) pickingCategory() bool {
+	return m.subscribeURL != "" || m.movingFeed != nil
+}
+
+// updateFeedTitleInput handles keys while a feed's new title is being typed
+func (m model) updateFeedTitleInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.renamingFeed = false
+		m.feedTitleInput.Blur()
+		return m, nil
+	case "enter":
+		m.renamingFeed = false
+		m.feedTitleInput.Blur()
+		title := strings.TrimSpace(m.feedTitleInput.Value())
+		if title == "" || m.feedsCursor >= len(m.feeds) {
+			return m, nil
+		}
+		return m, updateFeed(m.minifluxClient, m.feeds[m.feedsCursor].ID, &miniflux.FeedModificationRequest{Title: &title})
+	}
+
+	var cmd tea.Cmd
+	m.feedTitleInput, cmd = m.feedTitleInput.Update(msg)
+	return m, cmd
+}
+
+// updateFind handles keys while the find-in-article input is open
+func (m model) updateFind(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.finding = false
+		m.findInput.Blur()
+		return m, nil
+	case "enter":
+		m.finding = false
+		m.findInput.Blur()
+		m.findTerm = strings.TrimSpace(m.findInput.Value())
+		m.findMatches = findPhrase(m.content, m.findTerm)
+		if len(m.findMatches) == 0 {
+			if m.findTerm != "" {
+				m.notice = "no matches"
+			}
+			return m, nil
+		}
+
+		// Start from the current word so a match on screen counts
+		m.index--
+		return m.jumpToMatch(1), nil
+	}
+
+	var cmd tea.Cmd
+	m.findInput, cmd = m.findInput.Update(msg)
+	return m, cmd
+}
+
+// jumpToMatch moves to the next (dir 1) or previous (dir -1) match of the find term, wrapping around
+func (m model) jumpToMatch(dir int) model {
+	if len(m.findMatches) == 0 {
+		return m
+	}
+
+	target := -1
+	if dir > 0 {
+		for _, i := range m.findMatches {
+			if i > m.index {
+				target = i
+				break
+			}
+		}
+		if target < 0 {
+			target = m.findMatches[0]
+		}
+	} else {
+		for j := len(m.findMatches) - 1; j >= 0; j-- {
+			if m.findMatches[j] < m.index {
+				target = m.findMatches[j]
+				break
+			}
+		}
+		if target < 0 {
+			target = m.findMatches[len(m.findMatches)-1]
+		}
+	}
+
+	m = m.resetRhythm()
+	m.paused = true
+	m.index = target
+	return m
+}
+
+// matchPosition is the 1-based number of the match at the current word, or 0 if it isn't one
+func (m model) matchPosition() int {
+	for n, i := range m.findMatches {
+		if i == m.index {
+			return n + 1
+		}
+	}
+	return 0
+}
+
+// findPhrase returns the indices where the words of term appear in order, ignoring case and punctuation
+func findPhrase(words []string, term string) []int {
+	needle := strings.Fields(strings.ToLower(term))
+	if len(needle) == 0 {
+		return nil
+	}
+
+	var matches []int
+	for i := 0; i+len(needle) <= len(words); i++ {
+		matched := true
+		for j, part := range needle {
+			if !strings.Contains(strings.ToLower(words[i+j]), part) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// answerBreak handles the break reminder: Space carries on reading, t stays paused for a break.
+// Either way the next reminder comes after another BreakReminderMinutes of reading.
+func (m model) answerBreak(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case " ", "enter":
+		m.breakDue = false
+		m.lastBreakAt = m.activeReading
+		m.paused = false
+		return m, tick(m.currentDelay())
+	case "t":
+		m.breakDue = false
+		m.lastBreakAt = m.activeReading
+		m.notice = "on a break, Space to resume"
+	}
+	return m, nil
+}
+
+// resetRhythm clears the per-sentence state after the reading position jumps
+func (m model) resetRhythm() model {
+	m.punctBeat = false
+	m.finished = false
+	m.sentenceCount = 0
+	m.reflecting = false
+	return m
+}
+
+// modeIndicators sums up the reading modes that are on, e.g. "[R][W][B]": R ramping,
+// W wide or L block letters, B bionic, 3 the stacked layout, G glosses, A playlist auto-advance.
+// Zen mode hides the HUD, so it has no indicator.
+func (m model) modeIndicators() string {
+	var sb strings.Builder
+	mode := func(on bool, letter string) {
+		if on {
+			sb.WriteString("[" + letter + "]")
+		}
+	}
+	mode(m.rampSpeed, "R")
+	mode(m.textSize == TextWide, "W")
+	mode(m.textSize == TextBlock, "L")
+	mode(m.bionic, "B")
+	mode(m.cfg.ReadingLines == 3, "3")
+	mode(m.glossActive(), "G")
+	mode(m.playlistDir != "" && m.autoAdvance, "A")
+	return sb.String()
+}
+
+// entryHUDLine describes the article being read with the fields chosen in Config.HUDFields
+func (m model) entryHUDLine() string {
+	var parts []string
+	for _, field := range m.cfg.HUDFields {
+		switch field {
+		case HUDFieldTitle:
+			parts = append(parts, "Title: "+m.currentEntry.Title)
+		case HUDFieldFeed:
+			feed := m.feedTitle(m.currentEntry.FeedID)
+			if m.currentEntry.Feed != nil && m.currentEntry.Feed.Title != "" {
+				feed = m.currentEntry.Feed.Title
+			}
+			parts = append(parts, "Feed: "+feed)
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+// pauseMenuText lists the quick actions that apply to what is being read
+func (m model) pauseMenuText() string {
+	actions := []string{"Space: Resume", "g: Jump to Start"}
+	if m.currentEntry != nil {
+		actions = append(actions, "o: Open in Browser", "f: Star")
+		if m.minifluxClient != nil {
+			actions = append(actions, "m: Mark Read")
+		}
+	}
+	actions = append(actions, "p: Hide Menu")
+	return strings.Join(actions, "  "+glyph.Dot+"  ")
+}
+
+// seekRepeatWindow is the longest gap between seek keys that still counts as holding the key.
+// Terminals don't report key releases, so a gap this long is treated as one.
+const seekRepeatWindow = 250 * time.Millisecond
+
+// trackSeek records a seek in dir, continuing the streak if the key is being held
+func (m model) trackSeek(dir int) model {
+	now := time.Now()
+	if dir == m.seekDir && now.Sub(m.lastSeek) < seekRepeatWindow {
+		m.seekStreak++
+	} else {
+		m.seekStreak = 0
+	}
+	m.seekDir = dir
+	m.lastSeek = now
+	return m
+}
+
+// seekStep is how many words a seek moves: 10, or with SeekAcceleration 25 then 50 as the key is held
+func (m model) seekStep() int {
+	if !m.cfg.SeekAcceleration {
+		return 10
+	}
+	switch {
+	case m.seekStreak >= 15:
+		return 50
+	case m.seekStreak >= 5:
+		return 25
+	}
+	return 10
+}
+
+// metronomeBeat returns the command for a metronome beat on the word just shown, or nil
+// when the metronome is off, reduced motion is on, or the last beat was too recent
+func (m model) metronomeBeat() tea.Cmd {
+	if m.cfg.ReducedMotion || time.Since(m.lastPulse) < metronomeMinInterval {
+		return nil
+	}
+	switch m.cfg.Metronome {
+	case MetronomePulse:
+		return tea.Tick(metronomePulseLength, func(time.Time) tea.Msg { return pulseEndMsg{} })
+	case MetronomeBell:
+		return ringBell
+	}
+	return nil
+}
+
+func ringBell() tea.Msg {
+	fmt.Fprint(os.Stderr, "\a")
+	return nil
+}
+
+// beatDelay is how long the bare punctuation is shown in PunctuationBeat mode: one plain word at the current WPM
+func (m model) beatDelay() time.Duration {
+	return time.Minute / time.Duration(m.wpm)
+}
+
+// srtCues lays the content out as SRT subtitles, one cue per word with the same timing as the
+// reader, plus a cue for the bare punctuation when PunctuationBeat is on
+func (m model) srtCues() string {
+	var sb strings.Builder
+	var at time.Duration
+	cue := 0
+	addCue := func(text string, d time.Duration) {
+		cue++
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n\n", cue, srtTimestamp(at), srtTimestamp(at+d), text)
+		at += d
+	}
+
+	for i, word := range m.content {
+		addCue(word, m.wordDelayAt(i))
+		if m.cfg.PunctuationBeat && i < len(m.content)-1 {
+			if punct := sentenceEnding(word); punct != "" {
+				addCue(punct, m.beatDelay())
+			}
+		}
+	}
+	return sb.String()
+}
+
+// srtTimestamp formats d as an SRT timecode, HH:MM:SS,mmm
+func srtTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+// Punctuation that earns a pause, including the full-width forms used in Chinese and Japanese
+const (
+	sentenceMarks = ".!?。！？"
+	clauseMarks   = ",;，、；"
+	closingQuotes = `"')]}”’」』》）`
+)
+
+// textStructure describes the shape of a text, to judge how hard it will be before reading it
+type textStructure struct {
+	sentences   int
+	paragraphs  int
+	longestWord string
+}
+
+// structureOf counts the sentences and paragraphs in converted text and finds its longest word.
+// Paragraphs are runs of lines between blank lines; sentences end with sentence punctuation,
+// so abbreviations like "Mr." make the count an estimate.
+func structureOf(text string) textStructure {
+	var s textStructure
+	inParagraph := false
+	for line := range strings.Lines(text) {
+		if strings.TrimSpace(line) == "" {
+			inParagraph = false
+			continue
+		}
+		if !inParagraph {
+			s.paragraphs++
+			inParagraph = true
+		}
+	}
+
+	words := strings.Fields(text)
+	longest := 0
+	for _, word := range words {
+		if sentenceEnding(word) != "" {
+			s.sentences++
+		}
+		bare := strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		if n := utf8.RuneCountInString(bare); n > longest {
+			longest = n
+			s.longestWord = bare
+		}
+	}
+	// Text that trails off without a full stop still ends a sentence
+	if len(words) > 0 && sentenceEnding(words[len(words)-1]) == "" {
+		s.sentences++
+	}
+	return s
+}
+
+// sentenceEnding returns the sentence-ending punctuation a word finishes with (ignoring closing quotes), or ""
+func sentenceEnding(word string) string {
+	core := strings.TrimRight(word, closingQuotes)
+	stem := strings.TrimRight(core, sentenceMarks)
+	return core[len(stem):]
+}
+
+func tick(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// ORP Modes, choosing where in a word the focus letter falls
+const (
+	ORPBucket     = "bucket"     // By word length, roughly 35% in
+	ORPPercentage = "percentage" // A third of the way in
+	ORPCenter     = "center"     // The middle letter
+)
+
+// bucketFocus is the original ORP (Optimal Recognition Point) heuristic:
+// roughly 35% into the word, slightly adjusted for length
+func bucketFocus(n int) int {
+	switch {
+	case n <= 1:
+		return 0
+	case n <= 5:
+		return 1
+	case n <= 9:
+		return 2
+	case n <= 13:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// percentageFocus puts the focus a third of the way into the word, however long it is
+func percentageFocus(n int) int {
+	return n / 3
+}
+
+// centerFocus puts the focus on the middle letter, or the left of the two middle letters
+func centerFocus(n int) int {
+	return max(n-1, 0) / 2
+}
+
+// calculateORP splits a word around its focus letter, placed according to an ORP mode
+func calculateORP(word string, mode string) (string, string, string) {
+	runes := []rune(word)
+	n := len(runes)
+
+	// A symbol has no letter to focus on, so it is just centered
+	if isSymbolOnly(word) {
+		return string(runes[:n/2]), "", string(runes[n/2:])
+	}
+
+	var focusIdx int
+	switch mode {
+	case ORPPercentage:
+		focusIdx = percentageFocus(n)
+	case ORPCenter:
+		focusIdx = centerFocus(n)
+	default:
+		focusIdx = bucketFocus(n)
+	}
+
+	// Safety check
+	if focusIdx >= n {
+		focusIdx = n - 1
+	}
+
+	if n == 0 {
+		return "", "", ""
+	}
+
+	left := string(runes[:focusIdx])
+	focus := string(runes[focusIdx])
+	right := string(runes[focusIdx+1:])
+
+	return left, focus, right
+}
+
+// effectiveWPM is the speed actually achieved this session, including punctuation and ramping pauses.
+// Returns 0 when too little was read for the number to be meaningful.
+func (m model) effectiveWPM() int {
+	if m.activeReading < 30*time.Second {
+		return 0
+	}
+	return int(float64(m.wordsAdvanced) / m.activeReading.Minutes())
+}
+
+// focusScore is the share of reading time spent playing rather than manually paused, from 0 to 1
+func (m model) focusScore() float64 {
+	total := m.activeReading + m.pausedTotal
+	if total <= 0 {
+		return 0
+	}
+	return float64(m.activeReading) / float64(total)
+}
+
+// rereadSuggestThreshold is the re-read rate above which the summary suggests a lower WPM
+const rereadSuggestThreshold = 0.1
+
+// rereadRate is the share of words advanced that were rewound over to read again, from 0 to 1
+func (m model) rereadRate() float64 {
+	if m.wordsAdvanced == 0 {
+		return 0
+	}
+	return float64(m.wordsReread) / float64(m.wordsAdvanced)
+}
+
+// wpmTrend summarises the most recent sessions in history against the window before them
+func wpmTrend(history []int) string {
+	const window = 7
+	if len(history) == 0 {
+		return ""
+	}
+
+	recent := history[max(len(history)-window, 0):]
+	trend := fmt.Sprintf("Avg last %d sessions: %d WPM", len(recent), average(recent))
+
+	earlier := history[:len(history)-len(recent)]
+	if len(earlier) > 0 {
+		earlier = earlier[max(len(earlier)-window, 0):]
+		prev := average(earlier)
+		switch cur := average(recent); {
+		case cur > prev:
+			trend += fmt.Sprintf(", up from %d", prev)
+		case cur < prev:
+			trend += fmt.Sprintf(", down from %d", prev)
+		default:
+			trend += ", unchanged"
+		}
+	}
+	return trend
+}
+
+func average(values []int) int {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total / len(values)
+}
+
+func (m model) renderProgressBar() string {
+	total := len(m.content)
+	if total == 0 {
+		return ""
+	}
+	percent := float64(m.wordsDone()) / float64(total)
+	barWidth := 40
+	filled := int(percent * float64(barWidth))
+
+	bar := "[" + strings.Repeat("=", filled) + strings.Repeat("-", barWidth-filled) + "]"
+	return fmt.Sprintf("%s %d%%", bar, int(percent*100))
+}
+
+// Time Formats, choosing how the HUD shows reading time
+const (
+	TimeFormatRemaining = "remaining" // "Time Remaining: 04:17"
+	TimeFormatElapsed   = "elapsed"   // "01:23 / 05:40", elapsed out of the total
+	TimeFormatBoth      = "both"      // "01:23 / 05:40 (04:17 left)"
+)
+
+// renderTimeRemaining shows reading time in the configured format. Every figure is worked out
+// from the word position at the current speed, so it stays right when the WPM changes mid-read.
+func (m model) renderTimeRemaining() string {
+	elapsed := clockTime(m.wordsDone(), m.wpm)
+	total := clockTime(len(m.content), m.wpm)
+	remaining := clockTime(len(m.content)-m.wordsDone(), m.wpm)
+
+	switch m.cfg.TimeFormat {
+	case TimeFormatElapsed:
+		return elapsed + " / " + total
+	case TimeFormatBoth:
+		return fmt.Sprintf("%s / %s (%s left)", elapsed, total, remaining)
+	}
+	return "Time Remaining: " + remaining
+}
+
+// wordsDone counts the words already read: those before the current one, and the current one
+// too once the text is finished, so a finished read shows 100% even when it is a single word
+func (m model) wordsDone() int {
+	if m.finished {
+		return len(m.content)
+	}
+	return m.index
+}
+
+// clockTime is how long words take at wpm, as MM:SS
+func clockTime(words, wpm int) string {
+	seconds := int(float64(words) / float64(wpm) * 60)
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// Config
+type Config struct {
+	Version       int    `json:"version"`
+	WPM           int    `json:"wpm"`
+	ThemeIndex    int    `json:"theme_index"`
+	RampSpeed     bool   `json:"ramp_speed"`
+	ZenMode       bool   `json:"zen_mode"`
+	TextSize      int    `json:"text_size"` // 0 normal, 1 wide, 2 block letters
+	Bionic        bool   `json:"bionic"`    // Bold word starts rather than an ORP focus letter
+	TotalArticles int    `json:"total_articles"`
+	TotalWords    int    `json:"total_words"`
+	MinifluxURL   string `json:"miniflux_url"`
+	SetupComplete bool   `json:"setup_complete"`
+	HighestTier   int    `json:"highest_tier"`
+	TokenStore    string `json:"token_store"` // "keyring" (default) or "file"
+
+	// GlossCommand prints a short translation or definition of the word given as its last argument,
+	// shown under the word while reading at GlossMaxWPM or slower; empty turns glosses off
+	GlossCommand string `json:"gloss_command"`
+	GlossMaxWPM  int    `json:"gloss_max_wpm"`
+
+	// MediaPlayer is the command that plays an entry's media attachments, given the URL as its
+	// last argument; empty opens them in the browser
+	MediaPlayer string `json:"media_player"`
+
+	// SaveDir is where w saves articles as text files; empty saves them to "saved" next to the config
+	SaveDir string `json:"save_dir"`
+
+	// AutoTheme picks a light or dark preset at startup to match the macOS appearance
+	AutoTheme bool `json:"auto_theme"`
+
+	// Theme holds the active palette; older configs only have ThemeIndex and derive it from the preset
+	Theme *ThemeColors `json:"theme,omitempty"`
+
+	// RequestTimeoutSeconds limits how long a Miniflux request may take
+	RequestTimeoutSeconds int `json:"request_timeout_seconds"`
+
+	// UserAgent and HTTPHeaders are sent with every Miniflux request, for auth proxies in front of the server
+	UserAgent   string            `json:"user_agent,omitempty"`
+	HTTPHeaders map[string]string `json:"http_headers,omitempty"`
+
+	// PrefetchThreshold is how many entries from the end of the list the next page is requested
+	PrefetchThreshold int `json:"prefetch_threshold"`
+
+	// Metronome marks the reading rhythm with a "pulse" of the separators or a "bell"; "off" by default
+	Metronome string `json:"metronome"`
+	// ReducedMotion turns off visual effects such as the metronome
+	ReducedMotion bool `json:"reduced_motion"`
+
+	// ProgressGradient tints the word from GradientStart at the beginning of an article to GradientEnd
+	// at its end, both "#rrggbb"; the focus letter keeps its color
+	ProgressGradient bool   `json:"progress_gradient"`
+	GradientStart    string `json:"gradient_start"`
+	GradientEnd      string `json:"gradient_end"`
+
+	// Separator lines around the word: a single character, drawn "solid", "dashed" or "none"
+	SeparatorChar  string `json:"separator_char"`
+	SeparatorStyle string `json:"separator_style"`
+
+	// ORPMode places the focus letter: "bucket" by word length (default), "percentage" or "center"
+	ORPMode string `json:"orp_mode"`
+
+	// EscQuitsFromList makes Esc in the entry list quit, as it is the top level; when off only q quits
+	EscQuitsFromList bool `json:"esc_quits_from_list"`
+
+	// ListNumbers shows each entry's number in the list, for typing a number and Enter to jump to it
+	ListNumbers bool `json:"list_numbers"`
+
+	// ASCIIMode draws the interface with ASCII only, for terminals and fonts that garble other characters
+	ASCIIMode bool `json:"ascii_mode"`
+
+	// KeepANSI leaves terminal escape sequences in the text instead of stripping them
+	KeepANSI bool `json:"keep_ansi"`
+
+	// KeepInvisible leaves zero-width characters and no-break spaces in the text instead of normalizing them
+	KeepInvisible bool `json:"keep_invisible"`
+
+	// TimeFormat is how the HUD shows reading time: "remaining" (default), "elapsed" or "both"
+	TimeFormat string `json:"time_format"`
+
+	// URLHandling decides what happens to bare URLs in the text: "keep", "strip" or "domain"
+	URLHandling string `json:"url_handling"`
+
+	// EmphasisMultiplier lengthens the display time of words that were bold or italic, e.g. 1.15; 1 leaves them alone
+	EmphasisMultiplier float64 `json:"emphasis_multiplier"`
+
+	// CapsMultiplier lengthens the display time of ALL CAPS words, e.g. 1.3; 1 leaves them alone
+	CapsMultiplier float64 `json:"caps_multiplier"`
+
+	// BreakReminderMinutes pauses with a reminder to rest after this many minutes of reading; 0 turns it off
+	BreakReminderMinutes int `json:"break_reminder_minutes"`
+
+	// PositionSaveWords is how many words apart a local file's reading position is saved, so it
+	// can be resumed; it is also saved every few seconds and when the reader is left
+	PositionSaveWords int `json:"position_save_words"`
+
+	// ResumeRewind is how many words to step back when Space resumes reading
+	ResumeRewind int `json:"resume_rewind"`
+
+	// ReflectEvery pauses reading after this many sentences; 0 turns it off
+	ReflectEvery int `json:"reflect_every"`
+
+	// MinWPM and MaxWPM bound the reading speed
+	MinWPM int `json:"min_wpm"`
+	MaxWPM int `json:"max_wpm"`
+
+	// FeedColors colours entry titles in the list by feed ID, e.g. {"42": "#FF8800"}
+	FeedColors map[int64]string `json:"feed_colors,omitempty"`
+
+	// HUDFields picks what the reading HUD shows: "title" and "feed" of the article, and "modes",
+	// letters for the reading modes that are on; [] shows none of them
+	HUDFields []string `json:"hud_fields"`
+
+	// AutoPlayOnOpen starts reading as soon as an article or file has loaded, without waiting for Space
+	AutoPlayOnOpen bool `json:"auto_play_on_open"`
+
+	// SkipArticleInfo opens Miniflux articles in the reader, paused, instead of showing their length first
+	SkipArticleInfo bool `json:"skip_article_info"`
+
+	// QGoesBack makes q return to the list from an article, like Esc, instead of quitting
+	QGoesBack bool `json:"q_goes_back"`
+
+	// PauseMenu shows the quick-action menu whenever reading is paused (p toggles it)
+	PauseMenu bool `json:"pause_menu"`
+
+	// SeekAcceleration makes holding Left/Right seek further per step the longer it's held
+	SeekAcceleration bool `json:"seek_acceleration"`
+
+	// JoinHyphenation rejoins words hyphenated across a line break, like "inter-\nnational"
+	JoinHyphenation bool `json:"join_hyphenation"`
+
+	// CatchUpDays is how old unread entries must be for X to mark them read
+	CatchUpDays int `json:"catch_up_days"`
+
+	// MarkReadOpensNext makes M open the next entry rather than just moving the cursor to it
+	MarkReadOpensNext bool `json:"mark_read_opens_next"`
+
+	// Playlist options for reading a directory of files
+	PlaylistSort string `json:"playlist_sort"` // "name" (default) or "mtime"
+	AutoAdvance  bool   `json:"auto_advance"`  // Start the next file when one finishes
+
+	// OnFileFinish is what happens at the end of a file or pasted text: "pause", "loop", "list" or "quit"
+	OnFileFinish string `json:"on_file_finish"`
+
+	// AutoAdvanceDelaySeconds is the countdown before auto-advance opens the next file; 0 starts it at once
+	AutoAdvanceDelaySeconds int `json:"auto_advance_delay_seconds"`
+
+	// PunctuationBeat shows sentence-ending punctuation on its own after the word, as a clear stop signal
+	PunctuationBeat bool `json:"punctuation_beat"`
+
+	// ReadingLines is 1 for a single flashing word, or 3 to stack the previous and next words around it
+	ReadingLines int `json:"reading_lines"`
+
+	// PastWords is how the stacked layout shows the word before the current one: "show" (default), "dim" or "hide"
+	PastWords string `json:"past_words"`
+
+	// TierThresholds are the all-time word counts that earn a badge in the session summary
+	TierThresholds []int `json:"tier_thresholds"`
+
+	// WPMHistory holds the effective WPM of recent sessions, oldest first
+	WPMHistory []int `json:"wpm_history"`
+
+	// DailyStats holds what was read on each of the recent days something was, oldest first
+	DailyStats []dayStats `json:"daily_stats,omitempty"`
+}
+
+// wpmHistoryLimit caps Config.WPMHistory so the config stays compact
+const wpmHistoryLimit = 30
+
+// dayStats is what was read on one day, for the statistics dashboard
+type dayStats struct {
+	Date     string `json:"date"` // YYYY-MM-DD, local time
+	Articles int    `json:"articles"`
+	Words    int    `json:"words"`
+}
+
+// dailyStatsLimit caps Config.DailyStats to about a quarter of a year
+const dailyStatsLimit = 90
+
+const dayFormat = "2006-01-02"
+
+// withSession returns days with a session's reading added to the given day, oldest first
+func withSession(days []dayStats, at time.Time, articles, words int) []dayStats {
+	if articles == 0 && words == 0 {
+		return days
+	}
+	date := at.Format(dayFormat)
+	days = slices.Clone(days)
+	if n := len(days); n > 0 && days[n-1].Date == date {
+		days[n-1].Articles += articles
+		days[n-1].Words += words
+	} else {
+		days = append(days, dayStats{Date: date, Articles: articles, Words: words})
+	}
+	return days[max(len(days)-dailyStatsLimit, 0):]
+}
+
+// dayStatsFor returns the stats recorded for the day containing at, or zeroes
+func dayStatsFor(days []dayStats, at time.Time) dayStats {
+	date := at.Format(dayFormat)
+	for _, d := range days {
+		if d.Date == date {
+			return d
+		}
+	}
+	return dayStats{Date: date}
+}
+
+// readingStreak counts the days in a row with some reading, up to today.
+// A streak isn't broken until a whole day passes without reading, so it counts from yesterday if today is empty.
+func readingStreak(days []dayStats, now time.Time) int {
+	day := now
+	if dayStatsFor(days, day).Words == 0 {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for dayStatsFor(days, day).Words > 0 {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// configPathOverride is set from --config or SPEEDREADER_CONFIG; empty means the default location
+var configPathOverride string
+
+// resolveConfigPath picks the config path override, with the flag taking precedence over the environment
+func resolveConfigPath(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv("SPEEDREADER_CONFIG")
+}
+
+// checkConfigWritable verifies that files can be created in the directory holding the config
+func checkConfigWritable(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".speedreader-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// tierThresholds are the all-time word counts that unlock each badge tier, loaded from Config.TierThresholds
+var tierThresholds = defaultTierThresholds()
+
+func defaultTierThresholds() []int {
+	return []int{10000, 100000, 1000000}
+}
+
+// tierFor returns how many tier thresholds a word count has crossed (0 means no badge yet)
+func tierFor(words int) int {
+	tier := 0
+	for _, threshold := range tierThresholds {
+		if words >= threshold {
+			tier++
+		}
+	}
+	return tier
+}
+
+// formatThousands renders n with comma separators, e.g. 100000 -> "100,000"
+func formatThousands(n int) string {
+	if n < 0 {
+		return "-" + formatThousands(-n)
+	}
+	s := fmt.Sprint(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+func getConfigPath() string {
+	if configPathOverride != "" {
+		return configPathOverride
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "speedreader.json"
+	}
+	return filepath.Join(configDir, "speedreader.json")
+}
+
+// problemsLogPath is where entries flagged with ! are recorded, next to the config file
+func problemsLogPath() string {
+	return filepath.Join(filepath.Dir(getConfigPath()), "speedreader-problems.log")
+}
+
+// logProblem appends a line describing a misbehaving entry to the problems log.
+// words is how many words the reader got from it, or -1 if it hasn't been opened.
+func logProblem(entry *miniflux.Entry, feed string, words int) tea.Cmd {
+	return func() tea.Msg {
+		path := problemsLogPath()
+		wordsText := "not opened"
+		if words >= 0 {
+			wordsText = strconv.Itoa(words)
+		}
+		line := fmt.Sprintf("%s entry=%d feed=%d (%q) status=%s words=%s content_bytes=%d url=%s title=%q\n",
+			time.Now().Format(time.RFC3339), entry.ID, entry.FeedID, feed, entry.Status, wordsText, len(entry.Content), entry.URL, entry.Title)
+
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return problemLoggedMsg{path: path, err: err}
+		}
+		if _, err := f.WriteString(line); err != nil {
+			f.Close()
+			return problemLoggedMsg{path: path, err: err}
+		}
+		return problemLoggedMsg{path: path, err: f.Close()}
+	}
+}
+
+// savedTitle names the text being read, for the file w saves it to
+func (m model) savedTitle() string {
+	switch {
+	case m.currentEntry != nil:
+		return cleanTitle(m.currentEntry.Title)
+	case m.readingReturnState == StateFiles && m.playlistCursor < len(m.playlist):
+		return strings.TrimSuffix(m.playlist[m.playlistCursor].Name, filepath.Ext(m.playlist[m.playlistCursor].Name))
+	}
+	return "text"
+}
+
+// savedURL is the address of the entry being read, or empty for local text
+func (m model) savedURL() string {
+	if m.currentEntry != nil {
+		return m.currentEntry.URL
+	}
+	return ""
+}
+
+// saveArticle writes the words being read to a new text file in dir, named after the title,
+// with the title and URL at the top. An existing file is never overwritten; a number is added instead.
+func saveArticle(dir, title, url string, words []string) tea.Cmd {
+	return func() tea.Msg {
+		if dir == "" {
+			dir = filepath.Join(filepath.Dir(getConfigPath()), "saved")
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return articleSavedMsg{err: err}
+		}
+
+		text := title + "\n"
+		if url != "" {
+			text += url + "\n"
+		}
+		text += "\n" + wordsToText(words)
+
+		base := safeFilename(title)
+		for n := 1; ; n++ {
+			name := base + ".txt"
+			if n > 1 {
+				name = fmt.Sprintf("%s-%d.txt", base, n)
+			}
+			path := filepath.Join(dir, name)
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+			if errors.Is(err, os.ErrExist) {
+				continue
+			}
+			if err != nil {
+				return articleSavedMsg{err: err}
+			}
+			if _, err := f.WriteString(text); err != nil {
+				f.Close()
+				return articleSavedMsg{path: path, err: err}
+			}
+			return articleSavedMsg{path: path, err: f.Close()}
+		}
+	}
+}
+
+// safeFilename turns a title into a file name that is valid everywhere: letters and digits are kept,
+// runs of anything else become a single dash, and it is cut to a sensible length
+func safeFilename(title string) string {
+	const maxRunes = 80
+	var sb strings.Builder
+	dash := false
+	n := 0
+	for _, r := range title {
+		if n >= maxRunes {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		} else {
+			continue
+		}
+		n++
+	}
+	name := strings.TrimSuffix(sb.String(), "-")
+	if name == "" {
+		return "article"
+	}
+	return name
+}
+
+// configExists reports whether a config file has been written yet (used to detect a first run)
+func configExists() bool {
+	_, err := os.Stat(getConfigPath())
+	return err == nil
+}
+
+// configVersion is the schema version written by saveConfig; bump it when a migration is added
+const configVersion = 2
+
+const defaultWPM = 300
+
+// Default speed limits for k/j
+const (
+	defaultMinWPM = 50
+	defaultMaxWPM = 2000
+)
+
+// Default progress gradient, mid-bright so it reads on dark and light backgrounds alike
+const (
+	defaultGradientStart = "#5f87d7" // Blue
+	defaultGradientEnd   = "#5faf5f" // Green
+)
+
+// defaultEmphasisMultiplier lingers a little on bold and italic words
+const defaultEmphasisMultiplier = 1.15
+
+// autoPlayLeadIn is added to the first word's time when AutoPlayOnOpen starts reading
+const autoPlayLeadIn = 750 * time.Millisecond
+
+// defaultPositionSaveWords saves a file's reading position every few sentences
+const defaultPositionSaveWords = 50
+
+// defaultCatchUpDays is the age past which X marks unread entries read
+const defaultCatchUpDays = 30
+
+// defaultGlossMaxWPM is the fastest speed at which inline glosses are shown
+const defaultGlossMaxWPM = 200
+
+// defaultRequestTimeout is how many seconds a Miniflux request may take before it fails
+const defaultRequestTimeout = 60
+
+// defaultAutoAdvanceDelay is the countdown in seconds before the next playlist file starts
+const defaultAutoAdvanceDelay = 3
+
+// defaultPrefetchThreshold is how close to the end of the list the next page is fetched
+const defaultPrefetchThreshold = 10
+
+func defaultConfig() Config {
+	return Config{
+		Version:                 configVersion,
+		WPM:                     defaultWPM,
+		MinWPM:                  defaultMinWPM,
+		MaxWPM:                  defaultMaxWPM,
+		ReadingLines:            1,
+		PastWords:               PastWordsShow,
+		PrefetchThreshold:       defaultPrefetchThreshold,
+		AutoAdvanceDelaySeconds: defaultAutoAdvanceDelay,
+		RequestTimeoutSeconds:   defaultRequestTimeout,
+		GlossMaxWPM:             defaultGlossMaxWPM,
+		CapsMultiplier:          1,
+		EscQuitsFromList:        true,
+		PositionSaveWords:       defaultPositionSaveWords,
+		EmphasisMultiplier:      defaultEmphasisMultiplier,
+		CatchUpDays:             defaultCatchUpDays,
+		GradientStart:           defaultGradientStart,
+		GradientEnd:             defaultGradientEnd,
+		HUDFields:               defaultHUDFields(),
+		URLHandling:             URLKeep,
+		TimeFormat:              TimeFormatRemaining,
+		SeparatorChar:           defaultSeparatorChar,
+		SeparatorStyle:          SeparatorSolid,
+		TierThresholds:          defaultTierThresholds(),
+	}
+}
+
+func loadConfig() Config {
+	path := getConfigPath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return defaultConfig()
+	}
+
+	// Start from defaults so fields missing from older files keep sensible values
+	cfg := defaultConfig()
+	cfg.Version = 0 // Files written before versioning have no version field
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		// Keep a copy of the original before saveConfig replaces it with the recovered version
+		backupPath := path + ".bak"
+		if bakErr := os.WriteFile(backupPath, data, 0600); bakErr != nil {
+			configBackupFailed = true
+			fmt.Fprintf(os.Stderr, "Warning: config %s is corrupt (%v) and could not be backed up (%v); it will not be overwritten\n", path, err, bakErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: config %s is corrupt (%v); backed up to %s\n", path, err, backupPath)
+		}
+		cfg = recoverConfig(data)
+	}
+
+	return migrateConfig(cfg)
+}
+
+// configBackupFailed is set when a corrupt config could not be backed up, so it is never silently replaced
+var configBackupFailed bool
+
+// recoverConfig salvages each field that still parses from a config that failed to decode as a whole
+func recoverConfig(data []byte) Config {
+	cfg := defaultConfig()
+	cfg.Version = 0
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return cfg // Not a JSON object at all, nothing to salvage
+	}
+	for key, raw := range fields {
+		single, err := json.Marshal(map[string]json.RawMessage{key: raw})
+		if err != nil {
+			continue
+		}
+		_ = json.Unmarshal(single, &cfg) // A field with the wrong type keeps its default
+	}
+	return cfg
+}
+
+// migrateConfig upgrades configs from older versions and resets values that are out of range
+func migrateConfig(cfg Config) Config {
+	// Keep the speed range sensible and the wpm inside it
+	if cfg.MinWPM <= 0 {
+		cfg.MinWPM = defaultMinWPM
+	}
+	if cfg.MaxWPM < cfg.MinWPM {
+		cfg.MaxWPM = max(defaultMaxWPM, cfg.MinWPM)
+	}
+	if cfg.WPM <= 0 {
+		cfg.WPM = defaultWPM
+	}
+	cfg.WPM = min(max(cfg.WPM, cfg.MinWPM), cfg.MaxWPM)
+	cfg.ReflectEvery = max(cfg.ReflectEvery, 0)
+	cfg.ResumeRewind = max(cfg.ResumeRewind, 0)
+	if cfg.PositionSaveWords <= 0 {
+		cfg.PositionSaveWords = defaultPositionSaveWords
+	}
+	cfg.AutoAdvanceDelaySeconds = max(cfg.AutoAdvanceDelaySeconds, 0)
+	cfg.BreakReminderMinutes = max(cfg.BreakReminderMinutes, 0)
+	if cfg.CatchUpDays <= 0 {
+		cfg.CatchUpDays = defaultCatchUpDays
+	}
+	if cfg.EmphasisMultiplier <= 0 {
+		cfg.EmphasisMultiplier = defaultEmphasisMultiplier
+	}
+	if cfg.CapsMultiplier <= 0 {
+		cfg.CapsMultiplier = 1
+	}
+	if _, _, _, ok := parseHexColor(cfg.GradientStart); !ok {
+		cfg.GradientStart = defaultGradientStart
+	}
+	if _, _, _, ok := parseHexColor(cfg.GradientEnd); !ok {
+		cfg.GradientEnd = defaultGradientEnd
+	}
+	if cfg.RequestTimeoutSeconds <= 0 {
+		cfg.RequestTimeoutSeconds = defaultRequestTimeout
+	}
+	if cfg.HUDFields == nil {
+		cfg.HUDFields = defaultHUDFields()
+	}
+	// Version 2 added the mode indicators to the HUD; a HUD emptied on purpose stays empty
+	if cfg.Version < 2 && len(cfg.HUDFields) > 0 && !slices.Contains(cfg.HUDFields, HUDFieldModes) {
+		cfg.HUDFields = append(cfg.HUDFields, HUDFieldModes)
+	}
+	if cfg.PrefetchThreshold <= 0 {
+		cfg.PrefetchThreshold = defaultPrefetchThreshold
+	}
+	if cfg.TextSize < 0 || cfg.TextSize >= textSizeLevels {
+		cfg.TextSize = TextNormal
+	}
+	if cfg.ThemeIndex < 0 || cfg.ThemeIndex >= len(themes) {
+		cfg.ThemeIndex = 0
+	}
+	if cfg.Theme != nil {
+		// Fill in any colors missing from a hand-edited palette
+		derived := themeFor(lipgloss.Color(cfg.Theme.Background))
+		if cfg.Theme.Foreground == "" {
+			cfg.Theme.Foreground = derived.Foreground
+		}
+		if cfg.Theme.Focus == "" {
+			cfg.Theme.Focus = derived.Focus
+		}
+		if cfg.Theme.HUD == "" {
+			cfg.Theme.HUD = derived.HUD
+		}
+		if cfg.Theme.Line == "" {
+			cfg.Theme.Line = derived.Line
+		}
+	}
+	if cfg.TokenStore != TokenStoreFile {
+		cfg.TokenStore = TokenStoreKeyring
+	}
+	switch cfg.PastWords {
+	case PastWordsDim, PastWordsHide:
+	default:
+		cfg.PastWords = PastWordsShow
+	}
+	if cfg.ReadingLines != 3 {
+		cfg.ReadingLines = 1
+	}
+	if utf8.RuneCountInString(cfg.SeparatorChar) != 1 || lipgloss.Width(cfg.SeparatorChar) == 0 {
+		cfg.SeparatorChar = defaultSeparatorChar
+	}
+	switch cfg.SeparatorStyle {
+	case SeparatorDashed, SeparatorNone:
+	default:
+		cfg.SeparatorStyle = SeparatorSolid
+	}
+	switch cfg.OnFileFinish {
+	case FileFinishLoop, FileFinishList, FileFinishQuit:
+	default:
+		cfg.OnFileFinish = FileFinishPause
+	}
+	switch cfg.ORPMode {
+	case ORPPercentage, ORPCenter:
+	default:
+		cfg.ORPMode = ORPBucket
+	}
+	switch cfg.URLHandling {
+	case URLStrip, URLDomain:
+	default:
+		cfg.URLHandling = URLKeep
+	}
+	switch cfg.TimeFormat {
+	case TimeFormatElapsed, TimeFormatBoth:
+	default:
+		cfg.TimeFormat = TimeFormatRemaining
+	}
+	switch cfg.Metronome {
+	case MetronomePulse, MetronomeBell:
+	default:
+		cfg.Metronome = MetronomeOff
+	}
+	if cfg.PlaylistSort != PlaylistSortMTime {
+		cfg.PlaylistSort = PlaylistSortName
+	}
+	cfg.TotalArticles = max(cfg.TotalArticles, 0)
+	cfg.TotalWords = max(cfg.TotalWords, 0)
+
+	// Tiers must be positive and ascending for tierFor to count them
+	thresholds := make([]int, 0, len(cfg.TierThresholds))
+	for _, t := range cfg.TierThresholds {
+		if t > 0 {
+			thresholds = append(thresholds, t)
+		}
+	}
+	sort.Ints(thresholds)
+	cfg.TierThresholds = thresholds
+	cfg.HighestTier = min(max(cfg.HighestTier, 0), len(cfg.TierThresholds))
+
+	if len(cfg.WPMHistory) > wpmHistoryLimit {
+		cfg.WPMHistory = cfg.WPMHistory[len(cfg.WPMHistory)-wpmHistoryLimit:]
+	}
+
+	cfg.Version = configVersion
+	return cfg
+}
+
+func saveConfig(cfg Config) {
+	if configBackupFailed {
+		return
+	}
+	cfg.Version = configVersion
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err == nil {
+		writeFileAtomic(getConfigPath(), data, 0644)
+	}
+}
+
+// writeFileAtomic writes to a temp file in the same directory and renames it over path,
+// so a crash mid-write leaves either the old or the new file, never a truncated one
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return writeFileAtomicWith(path, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// writeFileAtomicWith is writeFileAtomic with the contents coming from write; if it fails, path is left as it was
+func writeFileAtomicWith(path string, perm os.FileMode, write func(io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := f.Name()
+	defer os.Remove(tmpName) // No-op once the rename has succeeded
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, path)
+}
 
-			links = append(links, ArticleLink{Text: text, URL: url, WordIndex: wordIndex})
+// printStorageLocations reports the resolved config path and token storage, for debugging persistence issues
+func printStorageLocations(cfg Config) {
+	configState := "missing"
+	if configExists() {
+		configState = "exists"
+	}
+	fmt.Printf("Config file:  %s (%s)\n", getConfigPath(), configState)
+	fmt.Printf("Feed cache:   %s\n", getFeedCachePath())
+	fmt.Printf("Token store:  %s\n", tokenStoreName(cfg))
+
+	tokenState := "no"
+	if tokenStoreName(cfg) == TokenStoreFile {
+		fmt.Printf("Token file:   %s\n", getTokenFilePath())
+		// Checking the file avoids prompting for the passphrase just to report status
+		if _, err := os.Stat(getTokenFilePath()); err == nil {
+			tokenState = "yes"
+		}
+	} else {
+		fmt.Printf("Keyring:      service %q, user %q\n", minifluxKeyringService, minifluxKeyringUser)
+		_, err := getMinifluxToken(cfg)
+		switch {
+		case err == nil:
+			tokenState = "yes"
+		case !errors.Is(err, keyring.ErrNotFound):
+			tokenState = fmt.Sprintf("unknown (%v)", err)
 		}
 	}
+	fmt.Printf("Token stored: %s\n", tokenState)
 
-	return links
+	if os.Getenv("MINIFLUX_URL") != "" || os.Getenv("MINIFLUX_API_TOKEN") != "" {
+		fmt.Println("Note: MINIFLUX_URL / MINIFLUX_API_TOKEN are set and take precedence over stored values.")
+	}
 }
 
-func markAsRead(client *miniflux.Client, entryID int64) tea.Cmd {
-	return func() tea.Msg {
-		err := client.UpdateEntries([]int64{entryID}, "read")
-		return markReadMsg{id: entryID, err: err}
+// Build information, set with -ldflags "-X main.version=... -X main.commit=... -X main.date=..."
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
+
+// printVersion reports the build version, falling back to the VCS stamp Go embeds when ldflags weren't set
+func printVersion() {
+	v, c, d := version, commit, date
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			v = info.Main.Version
+		}
+		for _, setting := range info.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && c == "":
+				c = setting.Value
+			case setting.Key == "vcs.time" && d == "":
+				d = setting.Value
+			}
+		}
 	}
-}
 
-func toggleStarred(client *miniflux.Client, entryID int64) tea.Cmd {
-	return func() tea.Msg {
-		err := client.ToggleStarred(entryID)
-		return starredMsg{id: entryID, err: err}
+	fmt.Printf("speedreader %s\n", v)
+	if c != "" {
+		fmt.Printf("commit:     %s\n", c)
+	}
+	if d != "" {
+		fmt.Printf("built:      %s\n", d)
 	}
+	fmt.Printf("go:         %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
 }
 
-// Logic Helpers
-
-func (m model) currentDelay() time.Duration {
-	baseDelay := 60.0 / float64(m.wpm)
-
-	word := m.content[m.index]
+// printDoctor shows what the terminal can draw: what it reports about itself, color samples,
+// and the wide and block characters the reader uses, so rendering problems can be pinned down
+func printDoctor() {
+	profile := lipgloss.ColorProfile().Name()
+	stat, err := os.Stdout.Stat()
+	tty := err == nil && stat.Mode()&os.ModeCharDevice != 0
 
-	// Complexity Ramping
-	if m.rampSpeed {
-		length := len(word)
-		switch {
-		case strings.Contains(word, "http"):
-			baseDelay *= 2.0
-		case length > 12:
-			baseDelay *= 1.5
-		case length > 8:
-			baseDelay *= 1.2
+	env := func(name string) string {
+		if value, ok := os.LookupEnv(name); ok {
+			return fmt.Sprintf("%q", value)
 		}
+		return "(unset)"
 	}
 
-	// Basic Punctuation detection
+	fmt.Println("Terminal")
+	fmt.Printf("  TERM:        %s\n", env("TERM"))
+	fmt.Printf("  COLORTERM:   %s\n", env("COLORTERM"))
+	fmt.Printf("  NO_COLOR:    %s\n", env("NO_COLOR"))
+	fmt.Printf("  Interactive: %t\n", tty)
+	fmt.Printf("  Colors:      %s\n", profile)
+	fmt.Printf("  Background:  %s\n", map[bool]string{true: "dark", false: "light"}[lipgloss.HasDarkBackground()])
+
+	fmt.Println("\nColors")
+	var row strings.Builder
+	for i := range 16 {
+		row.WriteString(lipgloss.NewStyle().Background(lipgloss.Color(fmt.Sprint(i))).Render("  "))
+	}
+	fmt.Println("  16:        " + row.String())
+	row.Reset()
+	for i := 16; i < 232; i += 9 {
+		row.WriteString(lipgloss.NewStyle().Background(lipgloss.Color(fmt.Sprint(i))).Render(" "))
+	}
+	fmt.Println("  256:       " + row.String())
+	row.Reset()
+	for i := range 24 {
+		c := blendHex(defaultGradientStart, defaultGradientEnd, float64(i)/23)
+		row.WriteString(lipgloss.NewStyle().Background(lipgloss.Color(c)).Render(" "))
+	}
+	fmt.Println("  Truecolor: " + row.String())
+	row.Reset()
+	for _, bg := range themes {
+		t := themeFor(bg)
+		row.WriteString(lipgloss.NewStyle().Background(lipgloss.Color(t.Background)).Foreground(lipgloss.Color(t.Focus)).Render(" Aa "))
+	}
+	fmt.Println("  Themes:    " + row.String())
+
+	// Each sample should end exactly at the bar below it
+	fmt.Println("\nCharacters (each line should end at the | under it)")
+	wide := toFullWidth("Speed 123")
+	fmt.Println("  Full-width: " + wide + "|")
+	fmt.Println("              " + strings.Repeat(" ", uniseg.StringWidth(wide)) + "|")
+	blocks := "█▉▊▋▌▍▎▏ ▁▂▃▄▅▆▇█"
+	fmt.Println("  Blocks:     " + blocks + "|")
+	fmt.Println("              " + strings.Repeat(" ", uniseg.StringWidth(blocks)) + "|")
+	glyphs := "▲▼★✓›·—…"
+	fmt.Println("  Symbols:    " + glyphs + "|")
+	fmt.Println("              " + strings.Repeat(" ", uniseg.StringWidth(glyphs)) + "|")
+	fmt.Println("  Progress:   " + (model{content: make([]string, 10), index: 4}).renderProgressBar())
+
+	fmt.Println()
 	switch {
-	case strings.HasSuffix(word, "."), strings.HasSuffix(word, "!"), strings.HasSuffix(word, "?"):
-		baseDelay *= 2.0
-	case strings.HasSuffix(word, ","), strings.HasSuffix(word, ";"):
-		baseDelay *= 1.5
+	case !tty:
+		fmt.Println("Output isn't a terminal, so the results above describe the pipe, not your terminal.")
+	case profile == "Ascii":
+		fmt.Println("No color support detected: themes and highlights won't show. Check TERM and NO_COLOR.")
+	case profile == "ANSI":
+		fmt.Println("Only 16 colors detected: themes are approximated. Setting COLORTERM=truecolor may help.")
+	default:
+		fmt.Println("If any line above is misaligned or shows boxes, your font lacks those characters.")
 	}
-
-	// Convert seconds to duration
-	return time.Duration(baseDelay * float64(time.Second))
 }
 
-func tick(d time.Duration) tea.Cmd {
-	return tea.Tick(d, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
-}
+// Subcommands
+const (
+	cmdRead   = "read"
+	cmdBrowse = "browse"
+	cmdStats  = "stats"
+	cmdConfig = "config"
+)
 
-func calculateORP(word string) (string, string, string) {
-	runes := []rune(word)
-	n := len(runes)
-	var focusIdx int
+func main() {
+	args := os.Args[1:]
 
-	// Simple heuristic for ORP (Optimal Recognition Point)
-	// Roughly 35% into the word, slightly adjusted for length
-	switch {
-	case n == 1:
-		focusIdx = 0
-	case n >= 2 && n <= 5:
-		focusIdx = 1
-	case n >= 6 && n <= 9:
-		focusIdx = 2
-	case n >= 10 && n <= 13:
-		focusIdx = 3
-	default:
-		focusIdx = 4
+	// Handled before anything else so it works even with a broken config or keyring
+	if len(args) > 0 && (args[0] == "--version" || args[0] == "-version" || args[0] == "version") {
+		printVersion()
+		return
 	}
-
-	// Safety check
-	if focusIdx >= n {
-		focusIdx = n - 1
+	if len(args) > 0 && (args[0] == "--doctor" || args[0] == "-doctor") {
+		printDoctor()
+		return
 	}
 
-	if n == 0 {
-		return "", "", ""
+	command := ""
+	if len(args) > 0 {
+		switch args[0] {
+		case cmdRead, cmdBrowse, cmdStats, cmdConfig:
+			command, args = args[0], args[1:]
+		}
 	}
 
-	left := string(runes[:focusIdx])
-	focus := string(runes[focusIdx])
-	right := string(runes[focusIdx+1:])
+	switch command {
+	case cmdRead:
+		runRead(args)
+	case cmdBrowse:
+		runBrowse(args)
+	case cmdStats:
+		runStats(args)
+	case cmdConfig:
+		runConfig(args)
+	default:
+		// `speedreader <file>` is shorthand for `read`, and no arguments at all browses Miniflux
+		runDefault(args)
+	}
+}
 
-	return left, focus, right
+// newFlagSet creates a subcommand flag set with the flags every subcommand shares
+func newFlagSet(name, usage string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s\n", usage)
+		fs.PrintDefaults()
+	}
+	configFlag := fs.String("config", "", "path to the config file (overrides $SPEEDREADER_CONFIG)")
+	return fs, configFlag
 }
 
-func (m model) renderProgressBar() string {
-	total := len(m.content)
-	if total == 0 {
-		return ""
+// applyConfigFlag resolves the config path override and warns if it can't be written
+func applyConfigFlag(flagValue string) {
+	configPathOverride = resolveConfigPath(flagValue)
+	if configPathOverride != "" {
+		if err := checkConfigWritable(configPathOverride); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: config directory for %s is not writable, settings will not be saved: %v\n", configPathOverride, err)
+		}
 	}
-	percent := float64(m.index) / float64(total)
-	barWidth := 40
-	filled := int(percent * float64(barWidth))
+}
 
-	bar := "[" + strings.Repeat("=", filled) + strings.Repeat("-", barWidth-filled) + "]"
-	return fmt.Sprintf("%s %d%%", bar, int(percent*100))
+// tuiOptions carries what the command line asked the TUI to open
+type tuiOptions struct {
+	content     string // Text to read, from stdin or a file
+	playlistDir string // Directory whose files are offered as a playlist
+	autoStart   bool   // Start reading without waiting for Space
+	fromWord    int    // First word to read, 1-based; 0 means the start
+	toWord      int    // Last word to read, inclusive; 0 means the end
+	csvPath     string // File to append finished articles to
+	srtPath     string // Write word timings here as subtitles instead of reading
+	path        string // File the content was read from, to remember the reading position in
 }
 
-func (m model) renderTimeRemaining() string {
-	wordsLeft := len(m.content) - m.index
-	minutes := float64(wordsLeft) / float64(m.wpm)
-	seconds := int(minutes * 60)
+// inputFlags are the flags shared by the commands that read text
+type inputFlags struct {
+	clipboard *bool
+	from      *int
+	to        *int
+	csv       *string
+	srt       *string
+}
 
-	return fmt.Sprintf("Time Remaining: %02d:%02d", seconds/60, seconds%60)
+func addCSVFlag(fs *flag.FlagSet) *string {
+	return fs.String("csv", "", "append a row per finished article (timestamp, title, url, words, wpm) to this CSV file")
 }
 
-// Config
-type Config struct {
-	WPM           int    `json:"wpm"`
-	ThemeIndex    int    `json:"theme_index"`
-	RampSpeed     bool   `json:"ramp_speed"`
-	ZenMode       bool   `json:"zen_mode"`
-	TotalArticles int    `json:"total_articles"`
-	TotalWords    int    `json:"total_words"`
-	MinifluxURL   string `json:"miniflux_url"`
+func addInputFlags(fs *flag.FlagSet) inputFlags {
+	return inputFlags{
+		clipboard: fs.Bool("clipboard", false, "read the current clipboard contents"),
+		from:      fs.Int("from", 0, "first word to read (1-based)"),
+		to:        fs.Int("to", 0, "last word to read"),
+		csv:       addCSVFlag(fs),
+		srt:       fs.String("srt", "", "write the text as timed SRT subtitles, one word per cue, then exit"),
+	}
 }
 
-func getConfigPath() string {
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		return "speedreader.json"
+// options gathers the input named by the flags and arguments
+func (f inputFlags) options(fs *flag.FlagSet) tuiOptions {
+	var opts tuiOptions
+	if *f.clipboard {
+		opts = clipboardInput()
+	} else {
+		opts = readInput(fs)
 	}
-	return filepath.Join(configDir, "speedreader.json")
+	opts.fromWord = *f.from
+	opts.toWord = *f.to
+	opts.csvPath = *f.csv
+	opts.srtPath = *f.srt
+
+	// Subtitles and word ranges work on the whole text up front
+	if opts.srtPath != "" || opts.fromWord != 0 || opts.toWord != 0 {
+		opts = opts.withContent()
+	}
+	return opts
 }
 
-func loadConfig() Config {
-	path := getConfigPath()
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return Config{WPM: 300, ThemeIndex: 0, TotalArticles: 0, TotalWords: 0, MinifluxURL: ""}
+// withContent reads the file argument now rather than once the TUI is up, exiting if it can't be read
+func (o tuiOptions) withContent() tuiOptions {
+	if o.path == "" || o.content != "" {
+		return o
 	}
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return Config{WPM: 300, ThemeIndex: 0, TotalArticles: 0, TotalWords: 0, MinifluxURL: ""}
+	content, err := readInputFile(o.path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", o.path, err)
+		os.Exit(1)
 	}
+	o.content = content
+	return o
+}
 
-	// make sure wpm doesnt go negative
-	cfg.WPM = max(cfg.WPM, 300)
+// wordRange returns words from..to (1-based, inclusive), clamped to the text; zero bounds mean the start and end
+func wordRange(words []string, from, to int) []string {
+	if from < 1 {
+		from = 1
+	}
+	if to <= 0 || to > len(words) {
+		to = len(words)
+	}
+	if from > to {
+		return nil
+	}
+	return words[from-1 : to]
+}
 
-	return cfg
+// hasInput reports whether anything local was given to read, as opposed to browsing Miniflux
+func (o tuiOptions) hasInput() bool {
+	return o.content != "" || o.path != "" || o.playlistDir != ""
 }
 
-func saveConfig(cfg Config) {
-	data, err := json.MarshalIndent(cfg, "", "  ")
-	if err == nil {
-		os.WriteFile(getConfigPath(), data, 0644)
+// clipboardInput reads the clipboard for --clipboard, exiting with a message if there's nothing to read
+func clipboardInput() tuiOptions {
+	text, err := readClipboard()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
+	return tuiOptions{content: text, autoStart: true}
 }
 
-func main() {
-	var fileContent string
-	var client *miniflux.Client
-	var minifluxURL string
-	var minifluxToken string
+// readInput collects piped stdin, the file argument, or a directory argument to use as a playlist
+func readInput(fs *flag.FlagSet) tuiOptions {
+	var opts tuiOptions
 
 	// 1. Check for stdin (piping)
 	stat, _ := os.Stdin.Stat()
@@ -1972,29 +6934,223 @@ func main() {
 			fmt.Printf("Error reading stdin: %v\n", err)
 			os.Exit(1)
 		}
-		fileContent = string(content)
-	} else if len(os.Args) > 1 {
-		// 2. Check for file argument
-		fileName := os.Args[1]
-		content, err := os.ReadFile(fileName)
+		opts.content = string(content)
+	} else if fs.NArg() > 0 {
+		// 2. Check for file or directory argument
+		fileName := fs.Arg(0)
+		if info, err := os.Stat(fileName); err == nil && info.IsDir() {
+			opts.playlistDir = fileName
+			return opts
+		}
+		info, err := os.Stat(fileName)
 		if err != nil {
 			fmt.Printf("Error reading file: %v\n", err)
 			os.Exit(1)
 		}
-		fileContent = string(content)
+		if info.Size() > maxInputFileSize {
+			fmt.Fprintf(os.Stderr, "Error: %s is %s MB, more than the %d MB the reader will load\n",
+				fileName, formatThousands(int(info.Size()>>20)), maxInputFileSize>>20)
+			os.Exit(1)
+		}
+
+		// The file itself is read once the TUI is up, which shows it loading meanwhile
+		opts.path = fileName
 	}
+	return opts
+}
+
+// maxInputFileSize is the largest file readInput accepts, and the most a Word document may unpack to;
+// every word is held in memory while reading
+const maxInputFileSize = 256 << 20
+
+// readInputFile reads a file named on the command line, extracting the text of Word documents
+func readInputFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".docx") {
+		return docxText(content)
+	}
+	return string(content), nil
+}
+
+func runDefault(args []string) {
+	fs, configFlag := newFlagSet("speedreader", "speedreader [read|browse|stats|config] [flags] [file]")
+	whereFlag := fs.Bool("where", false, "print where config and tokens are stored, then exit")
+	input := addInputFlags(fs)
+	fs.Parse(args)
+	applyConfigFlag(*configFlag)
+
+	if *whereFlag {
+		printStorageLocations(loadConfig())
+		return
+	}
+
+	opts := input.options(fs)
+	if opts.srtPath != "" {
+		runSRTExport(opts)
+		return
+	}
+	runTUI(opts)
+}
+
+func runRead(args []string) {
+	fs, configFlag := newFlagSet(cmdRead, "speedreader read [flags] <file|directory>  (or pipe text on stdin)")
+	input := addInputFlags(fs)
+	fs.Parse(args)
+	applyConfigFlag(*configFlag)
+
+	opts := input.options(fs)
+	if !opts.hasInput() {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if opts.srtPath != "" {
+		runSRTExport(opts)
+		return
+	}
+	runTUI(opts)
+}
+
+// runSRTExport times the input with the reader's delay model and writes it as subtitles
+func runSRTExport(opts tuiOptions) {
+	if opts.content == "" {
+		fmt.Fprintln(os.Stderr, "Error: --srt needs text from a file, stdin or --clipboard")
+		os.Exit(2)
+	}
+
+	cfg := loadConfig()
+	m := model{
+		content:   wordRange(prepareWords(opts.content, cfg), opts.fromWord, opts.toWord),
+		wpm:       cfg.WPM,
+		rampSpeed: cfg.RampSpeed,
+		cfg:       cfg,
+	}
+	if len(m.content) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no words to export")
+		os.Exit(2)
+	}
+
+	if err := os.WriteFile(opts.srtPath, []byte(m.srtCues()), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", opts.srtPath, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %d words to %s\n", len(m.content), opts.srtPath)
+}
+
+func runBrowse(args []string) {
+	fs, configFlag := newFlagSet(cmdBrowse, "speedreader browse [flags]")
+	csvFlag := addCSVFlag(fs)
+	fs.Parse(args)
+	applyConfigFlag(*configFlag)
+
+	runTUI(tuiOptions{csvPath: *csvFlag})
+}
+
+func runStats(args []string) {
+	fs, configFlag := newFlagSet(cmdStats, "speedreader stats [flags]")
+	dashboard := fs.Bool("dashboard", false, "show the statistics dashboard instead of printing a summary")
+	fs.Parse(args)
+	applyConfigFlag(*configFlag)
+
+	cfg := loadConfig()
+	tierThresholds = cfg.TierThresholds
+
+	if *dashboard {
+		applyStartupTheme(cfg)
+		applyGlyphs(cfg)
+		m := initialModel("", nil, cfg)
+		m.urlInput.Blur()
+		m.state = StateStats
+		if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+			fmt.Printf("Alas, there's been an error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Println("--- All-Time Statistics ---")
+	fmt.Printf("Articles Read: %d\n", cfg.TotalArticles)
+	fmt.Printf("Words Read:    %s\n", formatThousands(cfg.TotalWords))
+	if cfg.HighestTier > 0 {
+		fmt.Printf("Badge:         Tier %d (%s+ words)\n", cfg.HighestTier, formatThousands(tierThresholds[cfg.HighestTier-1]))
+	}
+	if trend := wpmTrend(cfg.WPMHistory); trend != "" {
+		fmt.Println(trend)
+	}
+}
+
+func runConfig(args []string) {
+	fs, configFlag := newFlagSet(cmdConfig, "speedreader config [flags]")
+	fs.Parse(args)
+	applyConfigFlag(*configFlag)
 
-	// Load Config (for MinifluxURL)
 	cfg := loadConfig()
-	currentTheme = cfg.ThemeIndex
-	if currentTheme >= len(themes) {
-		currentTheme = 0
+	printStorageLocations(cfg)
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		fmt.Printf("Error encoding config: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("\n%s\n", data)
+}
+
+// applyStartupTheme applies the theme from the config, preferring the saved palette so custom colors are kept
+func applyStartupTheme(cfg Config) {
+	currentTheme = cfg.ThemeIndex // Clamped to a valid preset by migrateConfig
+	if cfg.AutoTheme {
+		currentTheme = 0 // Default theme when the appearance is unknown
+		if dark, ok := systemDarkMode(); ok && dark {
+			currentTheme = autoThemeDark
+		} else if ok {
+			currentTheme = autoThemeLight
+		}
+		updateTheme(themeFor(themes[currentTheme]))
+	} else if cfg.Theme != nil {
+		updateTheme(*cfg.Theme)
+	} else {
+		updateTheme(themeFor(themes[currentTheme]))
 	}
+	if noColor {
+		// The theme above is still what gets saved, ready for when NO_COLOR is unset
+		applyNoColorStyles()
+	}
+}
+
+// applyNoColorStyles replaces the styles with plain ones that mark the focus letter and the
+// selected row with reverse video rather than color
+func applyNoColorStyles() {
+	focusStyle = lipgloss.NewStyle().Reverse(true).Bold(true)
+	normalStyle = lipgloss.NewStyle()
+	hudStyle = lipgloss.NewStyle().Align(lipgloss.Center)
+	lineStyle = lipgloss.NewStyle().Faint(true)
+	appStyle = lipgloss.NewStyle()
+	listSelectedStyle = lipgloss.NewStyle().Reverse(true)
+}
+
+// runTUI starts the interactive reader on the given input, or the Miniflux browser when there is none
+func runTUI(opts tuiOptions) {
+	var client *miniflux.Client
+	var minifluxURL string
+	var minifluxToken string
+
+	// Load Config (for MinifluxURL)
+	firstRun := !configExists()
+	cfg := loadConfig()
+	tierThresholds = cfg.TierThresholds
+	applyStartupTheme(cfg)
+	applyGlyphs(cfg)
 
-	updateTheme(themes[currentTheme]) // Apply initial theme
+	// The setup wizard comes first on a first run, so the file can't turn up halfway through it
+	if firstRun && !cfg.SetupComplete {
+		opts = opts.withContent()
+	}
+	fileContent := opts.content
 
 	// 2. Try to get Miniflux credentials
-	if fileContent == "" { // Only try Miniflux if no local file is given
+	if !opts.hasInput() { // Only try Miniflux if no local file is given
 		// Try from environment variables first
 		minifluxURL = os.Getenv("MINIFLUX_URL")
 		minifluxToken = os.Getenv("MINIFLUX_API_TOKEN")
@@ -2003,58 +7159,222 @@ func main() {
 		if minifluxURL == "" {
 			minifluxURL = cfg.MinifluxURL
 		}
+		// The passphrase is needed to read a saved token, or to save the one entered at login
+		if minifluxToken == "" && tokenStoreName(cfg) == TokenStoreFile {
+			if err := ensureTokenPassphrase(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading token file passphrase: %v\n", err)
+			}
+		}
 		if minifluxToken == "" && minifluxURL != "" { // Only try keyring if URL is present
 			var err error
-			minifluxToken, err = getMinifluxToken()
+			minifluxToken, err = getMinifluxToken(cfg)
 			if err != nil {
 				// Log error but don't exit, will go to login state
-				fmt.Fprintf(os.Stderr, "Error getting token from keyring: %v\n", err)
+				fmt.Fprintf(os.Stderr, "Error getting token from %s: %v\n", tokenStoreName(cfg), err)
+
+				// A broken keyring (as opposed to a missing token) would otherwise send us to login on every start
+				if tokenStoreName(cfg) != TokenStoreFile && !errors.Is(err, keyring.ErrNotFound) && offerFileTokenStore() {
+					cfg.TokenStore = TokenStoreFile
+					saveConfig(cfg)
+					if err := ensureTokenPassphrase(); err != nil {
+						fmt.Fprintf(os.Stderr, "Error reading token file passphrase: %v\n", err)
+					}
+				}
 			}
 		}
 
 		if minifluxURL != "" && minifluxToken != "" {
-			client = miniflux.NewClientWithOptions(
-				minifluxURL,
-				miniflux.WithAPIKey(minifluxToken),
-				miniflux.WithHTTPClient(&http.Client{Timeout: 60 * time.Second}),
-			)
+			client = newMinifluxClient(minifluxURL, minifluxToken, cfg)
 		}
 	}
 
 	m := initialModel(fileContent, client, cfg)
 
+	if fileContent != "" && (opts.fromWord != 0 || opts.toWord != 0) {
+		total := len(m.content)
+		m.content = wordRange(m.content, opts.fromWord, opts.toWord)
+		if len(m.content) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: --from %d --to %d selects no words (the text has %d)\n", opts.fromWord, opts.toWord, total)
+			os.Exit(2)
+		}
+	}
+
+	if opts.playlistDir != "" {
+		items, err := loadPlaylist(opts.playlistDir, cfg.PlaylistSort)
+		if err != nil {
+			fmt.Printf("Error reading directory: %v\n", err)
+			os.Exit(1)
+		}
+		m.state = StateFiles
+		m.urlInput.Blur()
+		m.playlistDir = opts.playlistDir
+		m.playlist = items
+	}
+	if opts.path != "" && opts.fromWord == 0 && opts.toWord == 0 {
+		m.positionFile, _ = filepath.Abs(opts.path)
+	}
+	if opts.path != "" && fileContent == "" {
+		// The position is restored once the file has loaded and its words can be counted
+		m.state = StateReading
+		m.loading = true
+		m.loadPath = opts.path
+	} else if m.positionFile != "" {
+		m = m.restorePosition()
+	}
+	if opts.autoStart && m.state == StateReading {
+		m.paused = false
+		m.currentFile = "Clipboard"
+	}
+
 	// If starting in login state, pre-fill from loaded config
 	if m.state == StateLogin {
 		m.urlInput.SetValue(minifluxURL)
 		// Token is not pre-filled into text input for security
 	}
 
+	// Show the setup wizard before anything else on a first run
+	if firstRun && !cfg.SetupComplete {
+		m.setupReturnState = m.state
+		m.state = StateSetup
+	}
+
 	p := tea.NewProgram(m, tea.WithAltScreen())
+
+	// Bubble Tea turns SIGINT and SIGTERM into a quit, but a closed terminal sends SIGHUP,
+	// which would otherwise kill us before the session is saved
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		if _, ok := <-hangup; ok {
+			p.Quit()
+		}
+	}()
+
 	finalModel, err := p.Run()
-	if err != nil {
-		fmt.Printf("Alas, there's been an error: %v", err)
-		os.Exit(1)
+	signal.Stop(hangup)
+	close(hangup)
+
+	// Progress is still saved after an interrupt or an error, as long as the model survived
+	exitCode := 0
+	if err != nil && !errors.Is(err, tea.ErrInterrupted) {
+		fmt.Printf("Alas, there's been an error: %v\n", err)
+		exitCode = 1
 	}
 
 	if m, ok := finalModel.(model); ok {
+		if m.positionDue(true) {
+			if err := storePosition(m.positionFile, m.index, len(m.content)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving reading position: %v\n", err)
+			}
+		}
+
+		// A pause still open at quit counts as paused up to now
+		if !m.pausedAt.IsZero() {
+			m.pausedTotal += time.Since(m.pausedAt)
+			m.pausedAt = time.Time{}
+		}
+
 		// Update cumulative stats and save
 		m.cfg.WPM = m.wpm
 		m.cfg.ThemeIndex = currentTheme
+		theme := activeTheme
+		m.cfg.Theme = &theme
 		m.cfg.RampSpeed = m.rampSpeed
 		m.cfg.ZenMode = m.zenMode
+		m.cfg.TextSize = m.textSize
+		m.cfg.Bionic = m.bionic
 		m.cfg.TotalArticles += m.sessionArticles
 		m.cfg.TotalWords += m.sessionWords
+		m.cfg.DailyStats = withSession(m.cfg.DailyStats, time.Now(), m.sessionArticles, m.sessionWords)
+
+		sessionWPM := m.effectiveWPM()
+		if sessionWPM > 0 {
+			m.cfg.WPMHistory = append(m.cfg.WPMHistory, sessionWPM)
+			if len(m.cfg.WPMHistory) > wpmHistoryLimit {
+				m.cfg.WPMHistory = m.cfg.WPMHistory[len(m.cfg.WPMHistory)-wpmHistoryLimit:]
+			}
+		}
+
+		previousTier := m.cfg.HighestTier
+		m.cfg.HighestTier = max(m.cfg.HighestTier, tierFor(m.cfg.TotalWords))
+
 		// MinifluxURL is updated earlier if in login state (m.cfg.MinifluxURL)
 		saveConfig(m.cfg)
 
+		if opts.csvPath != "" && len(m.sessionReads) > 0 {
+			if err := appendReadsCSV(opts.csvPath, m.sessionReads); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", opts.csvPath, err)
+			}
+		}
+
 		// Print Session Summary
 		fmt.Println("\n--- Session Summary ---")
 		fmt.Printf("Articles Read: %d\n", m.sessionArticles)
 		fmt.Printf("Words Read:    %d\n", m.sessionWords)
+		if sessionWPM > 0 {
+			fmt.Printf("Effective WPM: %d\n", sessionWPM)
+		}
+		if m.activeReading > 0 {
+			fmt.Printf("Pauses:        %d (%s paused)\n", m.pauseCount, m.pausedTotal.Round(time.Second))
+			fmt.Printf("Focus Score:   %d%%\n", int(m.focusScore()*100))
+		}
+		if m.rewindCount > 0 && m.wordsAdvanced > 0 {
+			rate := m.rereadRate()
+			fmt.Printf("Re-read Rate:  %d%% (%d rewinds)\n", int(rate*100), m.rewindCount)
+			if rate > rereadSuggestThreshold {
+				fmt.Println("               You rewound quite a lot; consider lowering your WPM.")
+			}
+		}
 		fmt.Println("-----------------------")
 		fmt.Printf("Total All-Time: %d articles, %d words\n", m.cfg.TotalArticles, m.cfg.TotalWords)
+		if trend := wpmTrend(m.cfg.WPMHistory); trend != "" {
+			fmt.Println(trend)
+		}
+
+		for tier := previousTier + 1; tier <= m.cfg.HighestTier; tier++ {
+			fmt.Printf(glyph.Star+" Milestone reached: %s words read! Congratulations!\n", formatThousands(tierThresholds[tier-1]))
+		}
+		if m.cfg.HighestTier > 0 {
+			fmt.Printf("Badge: Tier %d (%s+ words)\n", m.cfg.HighestTier, formatThousands(tierThresholds[m.cfg.HighestTier-1]))
+		}
+	}
+	if exitCode != 0 {
+		os.Exit(exitCode)
 	}
 }
+
+// Text Sizes, cycled with s
+const (
+	TextNormal = iota
+	TextWide   // Full-width characters
+	TextBlock  // Block letters from blockFont
+	textSizeLevels
+)
+
+// blockFontHeight is how many lines tall each blockFont glyph is
+const blockFontHeight = 5
+
+// blockFont draws letters, digits and common punctuation in rows separated by "|", with "#" filled
+var blockFont = map[rune]string{
+	'A': "###|#.#|###|#.#|#.#", 'B': "##.|#.#|##.|#.#|##.", 'C': "###|#..|#..|#..|###",
+	'D': "##.|#.#|#.#|#.#|##.", 'E': "###|#..|##.|#..|###", 'F': "###|#..|##.|#..|#..",
+	'G': "###|#..|#.#|#.#|###", 'H': "#.#|#.#|###|#.#|#.#", 'I': "###|.#.|.#.|.#.|###",
+	'J': "..#|..#|..#|#.#|###", 'K': "#.#|#.#|##.|#.#|#.#", 'L': "#..|#..|#..|#..|###",
+	'M': "#...#|##.##|#.#.#|#...#|#...#", 'N': "#..#|##.#|#.##|#..#|#..#", 'O': "###|#.#|#.#|#.#|###",
+	'P': "###|#.#|###|#..|#..", 'Q': "###|#.#|#.#|###|..#", 'R': "###|#.#|##.|#.#|#.#",
+	'S': "###|#..|###|..#|###", 'T': "###|.#.|.#.|.#.|.#.", 'U': "#.#|#.#|#.#|#.#|###",
+	'V': "#.#|#.#|#.#|#.#|.#.", 'W': "#...#|#...#|#.#.#|##.##|#...#", 'X': "#.#|#.#|.#.|#.#|#.#",
+	'Y': "#.#|#.#|.#.|.#.|.#.", 'Z': "###|..#|.#.|#..|###",
+	'0': "###|#.#|#.#|#.#|###", '1': ".#.|##.|.#.|.#.|###", '2': "###|..#|###|#..|###",
+	'3': "###|..#|###|..#|###", '4': "#.#|#.#|###|..#|..#", '5': "###|#..|###|..#|###",
+	'6': "###|#..|###|#.#|###", '7': "###|..#|..#|..#|..#", '8': "###|#.#|###|#.#|###",
+	'9': "###|#.#|###|..#|###",
+	'.': ".|.|.|.|#", ',': ".|.|.|#|#", '!': "#|#|#|.|#", '?': "###|..#|.##|...|.#.",
+	'\'': "#|#|.|.|.", '"': "#.#|#.#|...|...|...", '-': "...|...|###|...|...",
+	':': ".|#|.|#|.", ';': ".|#|.|#|#", '(': ".#|#.|#.|#.|.#", ')': "#.|.#|.#|.#|#.",
+	'/': "..#|..#|.#.|#..|#..",
+}
+
 func toFullWidth(s string) string {
 	var sb strings.Builder
 	for _, r := range s {
@@ -2070,44 +7390,31 @@ func toFullWidth(s string) string {
 	return sb.String()
 }
 
-func updateTheme(bg lipgloss.Color) {
-	// Determine foreground color based on background brightness
-	fgColor := lipgloss.Color("255")  // White text default
-	hudColor := lipgloss.Color("240") // Grey default
+func updateTheme(t ThemeColors) {
+	activeTheme = t
 
-	// Special handling for default terminal background
-	if bg == lipgloss.Color("") {
-		focusStyle = focusStyle.Background(lipgloss.NoColor{})
-		normalStyle = normalStyle.Background(lipgloss.NoColor{})
-		hudStyle = hudStyle.Background(lipgloss.NoColor{})
-		lineStyle = lineStyle.Background(lipgloss.NoColor{})
-		appStyle = appStyle.Background(lipgloss.NoColor{})
+	// An empty background means the terminal default, so styles carry no background at all
+	bg := themeColor(t.Background)
+	focusStyle = focusStyle.Background(bg)
+	normalStyle = normalStyle.Background(bg)
+	hudStyle = hudStyle.Background(bg)
+	lineStyle = lineStyle.Background(bg)
+	appStyle = appStyle.Background(bg)
 
-		// When background is default, we want our text to be readable on whatever the user has.
-		// For consistency, let's keep text bright (white) unless it's a light theme.
-		// So the fgColor logic still needs to run.
-		// We'll reset all backgrounds to NoColor and then let fg logic apply.
-	} else {
-		// Normal theme logic
-		// Very rough heuristic for light themes
-		if bg == lipgloss.Color("#ffffff") || bg == lipgloss.Color("#fbf1c7") {
-			fgColor = lipgloss.Color("0")    // Black text
-			hudColor = lipgloss.Color("238") // Darker grey for HUD
-		}
+	// Apply foreground colors after background is set
+	focusStyle = focusStyle.Foreground(themeColor(t.Focus))
+	normalStyle = normalStyle.Foreground(themeColor(t.Foreground))
+	hudStyle = hudStyle.Foreground(themeColor(t.HUD))
+	lineStyle = lineStyle.Foreground(themeColor(t.Line))
+	appStyle = appStyle.Foreground(themeColor(t.Foreground))
+}
 
-		focusStyle = focusStyle.Background(bg)
-		normalStyle = normalStyle.Background(bg)
-		hudStyle = hudStyle.Background(bg)
-		lineStyle = lineStyle.Background(bg)
-		appStyle = appStyle.Background(bg)
+// themeColor converts a palette entry to a lipgloss color, with empty meaning the terminal default
+func themeColor(c string) lipgloss.TerminalColor {
+	if c == "" {
+		return lipgloss.NoColor{}
 	}
-
-	// Apply foreground colors after background is set
-	focusStyle = focusStyle.Foreground(lipgloss.Color("196")) // Red remains red
-	normalStyle = normalStyle.Foreground(fgColor)
-	hudStyle = hudStyle.Foreground(hudColor)
-	lineStyle = lineStyle.Foreground(lipgloss.Color("238")) // Dark Grey remains dark grey
-	appStyle = appStyle.Foreground(fgColor)
+	return lipgloss.Color(c)
 }
 
 func shortDate(t time.Time) string {
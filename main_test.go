@@ -0,0 +1,702 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rivo/uniseg"
+	miniflux "miniflux.app/v2/client"
+)
+
+func TestWriteFileAtomicKeepsOriginalOnFailedWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "speedreader_config.json")
+	original := []byte(`{"wpm": 300}`)
+	if err := os.WriteFile(path, original, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Half the new config makes it out before the disk fills up
+	errDiskFull := errors.New("no space left on device")
+	err := writeFileAtomicWith(path, 0644, func(w io.Writer) error {
+		w.Write([]byte(`{"wpm": 4`))
+		return errDiskFull
+	})
+	if !errors.Is(err, errDiskFull) {
+		t.Fatalf("err = %v, want %v", err, errDiskFull)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(original) {
+		t.Errorf("config = %q after a failed write, want %q", got, original)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d files after a failed write, want only the config", len(entries))
+	}
+}
+
+func TestWriteFileAtomicReplacesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "speedreader_config.json")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(path, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "new" {
+		t.Errorf("file = %q, want %q", got, "new")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+// entriesWithIDs builds entries carrying only the given IDs
+func entriesWithIDs(ids ...int64) []*miniflux.Entry {
+	entries := make([]*miniflux.Entry, len(ids))
+	for i, id := range ids {
+		entries[i] = &miniflux.Entry{ID: id}
+	}
+	return entries
+}
+
+func TestAppendNewEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []int64
+		next    []int64
+		want    []int64
+	}{
+		{"disjoint pages", []int64{1, 2, 3}, []int64{4, 5}, []int64{1, 2, 3, 4, 5}},
+		{"page overlapping the last entries", []int64{1, 2, 3}, []int64{2, 3, 4, 5}, []int64{1, 2, 3, 4, 5}},
+		{"overlap out of order", []int64{10, 20, 30}, []int64{40, 20, 50, 10}, []int64{10, 20, 30, 40, 50}},
+		{"page repeating itself", []int64{1}, []int64{2, 2, 3}, []int64{1, 2, 3}},
+		{"nothing new", []int64{1, 2}, []int64{1, 2}, []int64{1, 2}},
+		{"first page", nil, []int64{7, 8}, []int64{7, 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := appendNewEntries(entriesWithIDs(tt.entries...), entriesWithIDs(tt.next...))
+			var got []int64
+			for _, entry := range merged {
+				got = append(got, entry.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("IDs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordDelay(t *testing.T) {
+	tests := []struct {
+		name string
+		word string
+		wpm  int
+		ramp bool
+		want time.Duration
+	}{
+		{"plain word", "hello", 600, false, 100 * time.Millisecond},
+		{"slower speed", "hello", 300, false, 200 * time.Millisecond},
+		{"faster speed", "hello", 1200, false, 50 * time.Millisecond},
+		{"sentence end", "hello.", 600, false, 200 * time.Millisecond},
+		{"question", "why?", 600, false, 200 * time.Millisecond},
+		{"clause", "hello,", 600, false, 150 * time.Millisecond},
+		{"semicolon", "first;", 600, false, 150 * time.Millisecond},
+		{"CJK full stop", "你好。", 600, false, 200 * time.Millisecond},
+		{"CJK comma", "你好，", 600, false, 150 * time.Millisecond},
+		{"long word without ramping", "extraordinary", 600, false, 100 * time.Millisecond},
+		{"long word ramped", "extraordinary", 600, true, 150 * time.Millisecond},
+		{"medium word ramped", "wonderful", 600, true, 120 * time.Millisecond},
+		{"short word ramped", "cat", 600, true, 100 * time.Millisecond},
+		{"link ramped", "https://example.com", 600, true, 200 * time.Millisecond},
+		{"ramped and sentence end", "extraordinary.", 600, true, 300 * time.Millisecond},
+		{"symbol skips the pause", "—", 600, true, 50 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wordDelay(tt.word, tt.wpm, tt.ramp, Config{})
+			if diff := got - tt.want; diff < -time.Microsecond || diff > time.Microsecond {
+				t.Errorf("wordDelay(%q, %d, %v) = %v, want %v", tt.word, tt.wpm, tt.ramp, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateToWidth(t *testing.T) {
+	const family = "\U0001F468\u200D\U0001F469\u200D\U0001F467" // One emoji, two cells wide
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"fits", "short", 10, "short"},
+		{"ascii", "hello world", 8, "hello w…"},
+		{"combining accent kept with its letter", "cafe\u0301 au lait", 5, "cafe\u0301…"},
+		{"combining accent not split off", "cafe\u0301 au lait", 4, "caf…"},
+		{"ZWJ emoji kept whole", family + family + " family", 4, family + "…"},
+		{"ZWJ emoji dropped whole", family + " family", 2, "…"},
+		{"CJK", "日本語のテキスト", 7, "日本語…"},
+		{"CJK wide character not halved", "日本語のテキスト", 6, "日本…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateToWidth(tt.s, tt.width)
+			if got != tt.want {
+				t.Errorf("truncateToWidth(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+			if w := uniseg.StringWidth(got); w > tt.width {
+				t.Errorf("truncateToWidth(%q, %d) is %d cells wide", tt.s, tt.width, w)
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"bat 256-color line", "\x1b[38;5;203mfunc\x1b[0m \x1b[38;5;149mmain\x1b[0m()", "func main()"},
+		{"truecolor and bold", "\x1b[1;38;2;255;100;0mWarning:\x1b[0m disk low", "Warning: disk low"},
+		{"OSC 8 hyperlink ended by ST", "see \x1b]8;;https://example.com\x1b\\the docs\x1b]8;;\x1b\\ now", "see the docs now"},
+		{"OSC 8 hyperlink ended by BEL", "\x1b]8;;https://example.com\x07link\x1b]8;;\x07", "link"},
+		{"plain text untouched", "no escapes [here]", "no escapes [here]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripANSI(tt.in); got != tt.want {
+				t.Errorf("stripANSI(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepareTextKeepANSI(t *testing.T) {
+	colored := "\x1b[38;5;203mfunc\x1b[0m main()"
+	cfg := Config{URLHandling: URLKeep}
+	if got := prepareText(colored, cfg); got != "func main()" {
+		t.Errorf("prepareText = %q, want the escapes stripped", got)
+	}
+	cfg.KeepANSI = true
+	if got := prepareText(colored, cfg); got != colored {
+		t.Errorf("prepareText with KeepANSI = %q, want %q", got, colored)
+	}
+}
+
+func TestSymbolOnlyTokensFromHTML(t *testing.T) {
+	// An em dash between words, and section breaks drawn with asterisks and dashes
+	html := "<p>One &mdash; two</p><hr><p>* * *</p><p>***</p><p>———</p><p>Three &rarr; four</p>"
+	words := prepareWords(convertContent(html, Config{URLHandling: URLKeep}).text, Config{URLHandling: URLKeep})
+
+	want := []string{"One", "—", "two", "*", "*", "*", "***", "———", "Three", "→", "four"}
+	if !slices.Equal(words, want) {
+		t.Fatalf("words = %q, want %q", words, want)
+	}
+
+	for _, word := range words {
+		symbol := isSymbolOnly(word)
+		if wantSymbol := !strings.ContainsFunc(word, unicode.IsLetter); symbol != wantSymbol {
+			t.Errorf("isSymbolOnly(%q) = %v, want %v", word, symbol, wantSymbol)
+		}
+		if !symbol {
+			continue
+		}
+
+		// No focus letter, and a shorter time on screen than a word without punctuation
+		if left, focus, right := calculateORP(word, ORPBucket); focus != "" || left+right != word {
+			t.Errorf("calculateORP(%q) = %q, %q, %q, want no focus letter", word, left, focus, right)
+		}
+		if got, plain := wordDelay(word, 600, true, Config{}), wordDelay("word", 600, true, Config{}); got >= plain {
+			t.Errorf("wordDelay(%q) = %v, want less than a plain word's %v", word, got, plain)
+		}
+	}
+}
+
+func TestIsSymbolOnly(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"—", true},
+		{"–", true},
+		{"***", true},
+		{"---", true},
+		{"→", true},
+		{"…", true},
+		{"", false},
+		{"word", false},
+		{"well—known", false},
+		{"1984", false},
+		{"—42", false},
+	}
+	for _, tt := range tests {
+		if got := isSymbolOnly(tt.word); got != tt.want {
+			t.Errorf("isSymbolOnly(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestRenderWordLineCentersSymbols(t *testing.T) {
+	for _, width := range []int{80, 81} {
+		m := model{width: width, cfg: Config{ORPMode: ORPBucket}}
+		for _, word := range []string{"—", "***", "———"} {
+			m.content = []string{word}
+			line := m.renderWordLine(word)
+			start := strings.Index(line, word)
+			if start < 0 {
+				t.Fatalf("renderWordLine(%q) = %q, word missing", word, line)
+			}
+			left := uniseg.StringWidth(line[:start])
+			right := uniseg.StringWidth(line[start+len(word):])
+			if diff := right - left; diff < 0 || diff > 1 {
+				t.Errorf("width %d: %q has %d cells to its left and %d to its right, want it centered", width, word, left, right)
+			}
+		}
+	}
+}
+
+func TestCalculateORPFocusIndex(t *testing.T) {
+	words := []string{"a", "the", "café", "reading", "wonderful", "extraordinary", "internationalization"}
+	tests := []struct {
+		mode string
+		want []int // Focus index for each of words
+	}{
+		{ORPBucket, []int{0, 1, 1, 2, 2, 3, 4}},
+		{ORPPercentage, []int{0, 1, 1, 2, 3, 4, 6}},
+		{ORPCenter, []int{0, 1, 1, 3, 4, 6, 9}},
+		{"", []int{0, 1, 1, 2, 2, 3, 4}}, // Unset falls back to bucket
+	}
+	for _, tt := range tests {
+		for i, word := range words {
+			left, focus, right := calculateORP(word, tt.mode)
+			runes := []rune(word)
+			if got := len([]rune(left)); got != tt.want[i] {
+				t.Errorf("calculateORP(%q, %q) focuses index %d, want %d", word, tt.mode, got, tt.want[i])
+				continue
+			}
+			if focus != string(runes[tt.want[i]]) || left+focus+right != word {
+				t.Errorf("calculateORP(%q, %q) = %q, %q, %q", word, tt.mode, left, focus, right)
+			}
+		}
+	}
+}
+
+// finishingModel is a model on the last word of entry, one tick from the end
+func finishingModel(entry *miniflux.Entry) model {
+	return model{
+		state:          StateReading,
+		wpm:            300,
+		content:        []string{"the", "end"},
+		index:          1,
+		minifluxClient: miniflux.NewClient("http://127.0.0.1:1", "token"),
+		currentEntry:   entry,
+		entries:        []*miniflux.Entry{entry, {ID: 2}},
+		totalEntries:   2,
+	}
+}
+
+func TestFinishingReadEntryDoesNotMarkIt(t *testing.T) {
+	updated, cmd := finishingModel(&miniflux.Entry{ID: 1, Status: miniflux.EntryStatusRead}).update(tickMsg(time.Now()))
+	m := updated.(model)
+	if cmd != nil {
+		t.Errorf("finishing an entry already read returned a command %T, want none", cmd())
+	}
+	if !m.finished {
+		t.Error("finished = false at the end of the entry")
+	}
+	if m.totalEntries != 2 || len(m.entries) != 2 {
+		t.Errorf("totalEntries = %d with %d entries, want both unchanged at 2", m.totalEntries, len(m.entries))
+	}
+}
+
+func TestFinishingUnreadEntryMarksIt(t *testing.T) {
+	_, cmd := finishingModel(&miniflux.Entry{ID: 1, Status: miniflux.EntryStatusUnread}).update(tickMsg(time.Now()))
+	if cmd == nil {
+		t.Error("finishing an unread entry returned no command, want it marked read")
+	}
+}
+
+func TestIsAllCaps(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"NASA.", true},
+		{"NASA", true},
+		{"(FBI)", true},
+		{"U.S.", true},
+		{"I", false},
+		{"A", false},
+		{"Hello", false},
+		{"iPhone", false},
+		{"123", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isAllCaps(tt.word); got != tt.want {
+			t.Errorf("isAllCaps(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestSingleWordFinishes(t *testing.T) {
+	configPathOverride = filepath.Join(t.TempDir(), "speedreader_config.json")
+	t.Cleanup(func() { configPathOverride = "" })
+
+	m := initialModel("Hello", nil, loadConfig())
+	m.width, m.height = 100, 30
+	m.paused = false
+	if got := m.renderProgressBar(); !strings.HasSuffix(got, " 0%") {
+		t.Errorf("progress before reading = %q, want 0%%", got)
+	}
+
+	updated, _ := m.update(tickMsg(time.Now()))
+	m = updated.(model)
+	if !m.finished || !m.paused {
+		t.Fatalf("finished = %v, paused = %v after the only word, want both true", m.finished, m.paused)
+	}
+	if got := m.renderProgressBar(); !strings.HasSuffix(got, " 100%") {
+		t.Errorf("progress after the only word = %q, want 100%%", got)
+	}
+	if got := m.renderTimeRemaining(); got != "Time Remaining: 00:00" {
+		t.Errorf("time after the only word = %q, want none remaining", got)
+	}
+	if view := m.View(); !strings.Contains(view, "FINISHED") {
+		t.Errorf("view after the only word has no FINISHED status:\n%s", view)
+	}
+}
+
+func TestNormalizeWords(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"no-break spaces", "10\u00a0km to\u00a0the\u202fend", []string{"10", "km", "to", "the", "end"}},
+		{"zero-width spaces", "\ufeffzero\u200bwidth \u200b spaces\u200b", []string{"zerowidth", "spaces"}},
+		{"tab-heavy", "\tname\t\tvalue\t\n\t\tnext\t \t row\t", []string{"name", "value", "next", "row"}},
+		{"soft hyphen", "hy\u00adphen", []string{"hyphen"}},
+		{"only invisible", "\u200b\u00a0\t\u200b", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeWords(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("normalizeWords(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScrollWindow(t *testing.T) {
+	tests := []struct {
+		name                  string
+		cursor, total, height int
+		wantStart, wantEnd    int
+	}{
+		{"top", 0, 10, 5, 0, 5},
+		{"last row of first window", 4, 10, 5, 0, 5},
+		{"scrolled one", 5, 10, 5, 1, 6},
+		{"bottom", 9, 10, 5, 5, 10},
+		{"cursor past the end", 15, 10, 5, 5, 10},
+		{"negative cursor", -3, 10, 5, 0, 5},
+		{"fewer items than rows", 2, 3, 5, 0, 3},
+		{"no height", 3, 10, 0, 3, 4},
+		{"empty list", 0, 0, 5, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := scrollWindow(tt.cursor, tt.total, tt.height)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("scrollWindow(%d, %d, %d) = %d, %d, want %d, %d",
+					tt.cursor, tt.total, tt.height, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestViewSearchingKeepsLongNamesToOneLine(t *testing.T) {
+	m := initialModel("", nil, Config{})
+	m.state = StateSearching
+	m.searchMode = SearchFeed
+	m.width, m.height = 60, 20
+	for i := range 30 {
+		m.filteredList = append(m.filteredList, fmt.Sprintf("Feed %02d %s", i, strings.Repeat("very long feed name ", 10)))
+		m.filteredIDs = append(m.filteredIDs, int64(i+1))
+	}
+	m.searchCursor = 25
+
+	view := m.viewSearching()
+	if h := lipgloss.Height(view); h != m.height {
+		t.Errorf("view is %d lines tall, want %d", h, m.height)
+	}
+	rows := 0
+	for line := range strings.SplitSeq(view, "\n") {
+		if w := lipgloss.Width(line); w > m.width {
+			t.Errorf("line is %d cells wide, want at most %d: %q", w, m.width, line)
+		}
+		if strings.Contains(line, "Feed ") {
+			rows++
+		}
+	}
+	if want := m.height - 7; rows != want { // Header, input and spacing, and a footer wrapped to two lines
+		t.Errorf("view lists %d feeds, want %d, one per line", rows, want)
+	}
+	if !strings.Contains(view, "> Feed 25") {
+		t.Error("the feed under the cursor is not in view")
+	}
+}
+
+func TestRemoveReadKeepsHighlightedEntry(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove []int64
+		want   int64
+	}{
+		{"one above the cursor", []int64{2}, 4},
+		{"several above the cursor", []int64{1, 3}, 4},
+		{"below the cursor", []int64{5}, 4},
+		{"above and below", []int64{1, 5}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{entries: entriesWithIDs(1, 2, 3, 4, 5), totalEntries: 5, cursor: 3, listOffset: 2}
+			m = m.removeRead(tt.remove...)
+			if got := m.highlightedID(); got != tt.want {
+				t.Errorf("highlighted entry = %d after removing %v, want %d", got, tt.remove, tt.want)
+			}
+			if m.totalEntries != 5-len(tt.remove) {
+				t.Errorf("totalEntries = %d, want %d", m.totalEntries, 5-len(tt.remove))
+			}
+			if m.listOffset > m.cursor {
+				t.Errorf("listOffset %d is past the cursor at %d", m.listOffset, m.cursor)
+			}
+		})
+	}
+}
+
+func TestMigrateConfigAddsModesToHUD(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		fields  []string
+		want    []string
+	}{
+		{"version 1 fields", 1, []string{HUDFieldTitle}, []string{HUDFieldTitle, HUDFieldModes}},
+		{"unversioned fields", 0, []string{HUDFieldFeed}, []string{HUDFieldFeed, HUDFieldModes}},
+		{"already there", 1, []string{HUDFieldModes, HUDFieldTitle}, []string{HUDFieldModes, HUDFieldTitle}},
+		{"emptied on purpose", 1, []string{}, []string{}},
+		{"removed since version 2", 2, []string{HUDFieldTitle}, []string{HUDFieldTitle}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultConfig()
+			cfg.Version = tt.version
+			cfg.HUDFields = tt.fields
+			if got := migrateConfig(cfg).HUDFields; !slices.Equal(got, tt.want) {
+				t.Errorf("HUDFields = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// listAfterReading is a model back on the list of entries 1-3 after reading entry 9
+func listAfterReading() model {
+	return model{
+		state:          StateBrowsing,
+		minifluxClient: miniflux.NewClient("http://127.0.0.1:1", "token"),
+		currentEntry:   &miniflux.Entry{ID: 9},
+		content:        []string{"an", "old", "article"},
+		entries:        entriesWithIDs(1, 2, 3),
+		totalEntries:   3,
+		cursor:         1,
+	}
+}
+
+func TestStarSelectedAfterReading(t *testing.T) {
+	m := listAfterReading()
+	m.selected = map[int64]bool{1: true, 3: true}
+	updated, cmd := m.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if cmd == nil {
+		t.Fatal("f with a selection returned no command")
+	}
+	if got := updated.(model).selected; len(got) != 0 {
+		t.Errorf("selection = %v after f, want it starred and cleared", got)
+	}
+}
+
+func TestKeyEntryFollowsState(t *testing.T) {
+	m := listAfterReading()
+	if got := m.keyEntry(); got == nil || got.ID != 2 {
+		t.Errorf("keyEntry in the list = %v, want the highlighted entry 2", got)
+	}
+	m.state = StateReading
+	if got := m.keyEntry(); got == nil || got.ID != 9 {
+		t.Errorf("keyEntry while reading = %v, want the open entry 9", got)
+	}
+	m.state = StateBrowsing
+	m.entries = nil
+	if got := m.keyEntry(); got != nil {
+		t.Errorf("keyEntry in an empty list = %v, want nil", got)
+	}
+}
+
+func TestAttachmentFromListUsesHighlightedEntry(t *testing.T) {
+	m := listAfterReading()
+	m.currentEntry.Enclosures = miniflux.Enclosures{{URL: "http://127.0.0.1:1/old.mp3"}}
+	updated, cmd := m.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if cmd != nil {
+		t.Error("a in the list opened the last article's attachment, want the highlighted entry's")
+	}
+	if got := updated.(model).notice; got != "no media attached" {
+		t.Errorf("notice = %q, want %q for the highlighted entry", got, "no media attached")
+	}
+}
+
+func TestLoadInputFileSplitsWords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "book.txt")
+	if err := os.WriteFile(path, []byte("One two.\n\nThree four five."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := loadInputFile(path, Config{})().(contentMsg)
+	if !ok {
+		t.Fatalf("loadInputFile returned %T, want contentMsg", msg)
+	}
+	if want := []string{"One", "two.", "Three", "four", "five."}; !slices.Equal(msg.words, want) {
+		t.Errorf("words = %q, want %q", msg.words, want)
+	}
+	if msg.structure.sentences != 2 || msg.structure.paragraphs != 2 {
+		t.Errorf("structure = %+v, want 2 sentences in 2 paragraphs", msg.structure)
+	}
+}
+
+func TestDocxTextRefusesLargeDocument(t *testing.T) {
+	body := []byte(`<w:document><w:body><w:p><w:r><w:t>small</w:t></w:r></w:p></w:body></w:document>`)
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	// The header claims more than the data holds, as a zip bomb's would
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "word/document.xml",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(body),
+		CompressedSize64:   uint64(len(body)),
+		UncompressedSize64: maxInputFileSize + 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(body)
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := docxText(buf.Bytes()); err == nil || !strings.Contains(err.Error(), "unpacked") {
+		t.Errorf("docxText error = %v, want it refusing a document that unpacks past maxInputFileSize", err)
+	}
+}
+
+func TestStructureOfEmphasizedSentenceEnd(t *testing.T) {
+	msg := convertContent("<p>Hello <b>world.</b></p><p>It ends in <em>italics!</em> Then plain.</p>", Config{})
+	if msg.structure.sentences != 3 || msg.structure.paragraphs != 2 {
+		t.Errorf("structure = %+v, want 3 sentences in 2 paragraphs", msg.structure)
+	}
+}
+
+func TestLargeTextStaysASCII(t *testing.T) {
+	cfg := Config{ORPMode: ORPBucket, ASCIIMode: true}
+	applyGlyphs(cfg)
+	t.Cleanup(func() { applyGlyphs(Config{}) })
+
+	m := model{width: 80, cfg: cfg, content: []string{"Hello"}}
+	m.textSize = TextWide
+	lines := []string{m.renderWordLine("Hello"), m.contextWord("world")}
+	m.textSize = TextBlock
+	block, ok := m.renderBlockWord("Hello")
+	if !ok {
+		t.Fatal("renderBlockWord(\"Hello\") didn't fit in 80 columns")
+	}
+	for _, line := range append(lines, block...) {
+		for _, r := range stripANSI(line) {
+			if r > unicode.MaxASCII {
+				t.Errorf("line %q has %q with ASCIIMode on", line, r)
+				break
+			}
+		}
+	}
+}
+
+func TestNextEntryWaitsForPageFromEmptyList(t *testing.T) {
+	// The last loaded entry was finished and dropped, so J has to wait for the next page
+	m := model{
+		state:              StateReading,
+		minifluxClient:     miniflux.NewClient("http://127.0.0.1:1", "token"),
+		currentEntry:       &miniflux.Entry{ID: 9},
+		readingReturnState: StateBrowsing,
+		contentCache:       newContentCache(contentCacheLimit),
+		entriesOffset:      10,
+		totalEntries:       20,
+	}
+	updated, _ := m.openAdjacentEntry(1)
+	m = updated.(model)
+	if !m.fetchingMore {
+		t.Fatal("J at the end of the list didn't fetch the next page")
+	}
+
+	page := &miniflux.EntryResultSet{Total: 20, Entries: entriesWithIDs(11, 12)}
+	updated, _ = m.update(entriesMsg{result: page, offset: 10, nextOffset: 12})
+	m = updated.(model)
+	if m.currentEntry.ID != 11 || m.cursor != 0 {
+		t.Errorf("opened entry %d with the cursor at %d, want the first entry of the page, 11", m.currentEntry.ID, m.cursor)
+	}
+	if m.pendingNext {
+		t.Error("pendingNext still set after the entry opened")
+	}
+}
+
+func TestCJKBreakAfterPunctuationRuns(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"你好。我很好", []string{"你好。", "我很好"}},
+		{"真的！？不会吧", []string{"真的！？", "不会吧"}},
+		{"他说「好。」然后走了", []string{"他说「好。」", "然后走了"}},
+		{"什么？！」他问", []string{"什么？！」", "他问"}},
+		{"结束。", []string{"结束。"}},
+	}
+	for _, tt := range tests {
+		if got := prepareWords(tt.text, Config{}); !slices.Equal(got, tt.want) {
+			t.Errorf("prepareWords(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}